@@ -93,6 +93,8 @@ func main() {
 	// TODO can we normalize all of these flags?
 	p.Cmd.PersistentFlags().StringVar(&c.KubeConfigFile, cli.StripDash(flags.KubeConfigFlagName), "", "kubeconfig `file` (default is $HOME/.kube/config)")
 	p.Cmd.MarkFlagFilename(cli.StripDash(flags.KubeConfigFlagName))
+	p.Cmd.PersistentFlags().StringVar(&c.ViperConfigFile, cli.StripDash(flags.ConfigFlagName), "", "project config `file` with default values for workload flags, only the namespace is used by the workload commands without workload flags (default is the first "+commands.ProjectConfigFileName+" found in the working directory or its parents)")
+	p.Cmd.MarkFlagFilename(cli.StripDash(flags.ConfigFlagName))
 	p.Cmd.PersistentFlags().StringVar(&c.CurrentContext, cli.StripDash(flags.ContextFlagName), "", "`name` of the kubeconfig context to use (default is current-context defined by kubeconfig)")
 	p.Cmd.PersistentFlags().BoolVar(&color.NoColor, cli.StripDash(flags.NoColorFlagName), color.NoColor, "disable color output in terminals")
 	p.Cmd.PersistentFlags().Int32VarP(c.Verbose, cli.StripDash(flags.VerboseLevelFlagName), "v", 1, "number for the log level verbosity")
@@ -128,7 +130,8 @@ func main() {
 		// silent errors should not log, but still exit with an error code
 		// typically the command has already been logged with more detail
 		if !errors.Is(err, cli.SilentError) {
-			if aggregate, ok := err.(utilerrors.Aggregate); ok {
+			var aggregate utilerrors.Aggregate
+			if errors.As(err, &aggregate) {
 				for _, err := range aggregate.Errors() {
 					c.Eprintf("%s %s\n", printer.Serrorf("Error:"), err)
 				}
@@ -138,6 +141,6 @@ func main() {
 				c.Eprintf("%s %s\n", printer.Serrorf("Error:"), err)
 			}
 		}
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -47,14 +47,49 @@ func (w *Workload) Load(in io.Reader) error {
 		return err
 	}
 
-	if apiVersion, kind := SchemeGroupVersion.Identifier(), "Workload"; w.APIVersion != apiVersion || w.Kind != kind {
-		return fmt.Errorf("file must contain resource with API Version %q and Kind %q", apiVersion, kind)
+	return w.clearTypeMeta()
+}
+
+// LoadWorkloads reads every workload described in a stream of yaml or json documents, in the order
+// they appear. Empty documents are ignored.
+func LoadWorkloads(in io.Reader) ([]*Workload, error) {
+	d := yaml.NewYAMLOrJSONDecoder(in, 4096)
+	workloads := []*Workload{}
+	for {
+		var workload *Workload
+		if err := d.Decode(&workload); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if workload == nil {
+			continue
+		}
+		if err := workload.clearTypeMeta(); err != nil {
+			return nil, err
+		}
+		workloads = append(workloads, workload)
+	}
+	if len(workloads) == 0 {
+		return nil, errWorkloadTypeMeta()
+	}
+	return workloads, nil
+}
+
+func (w *Workload) clearTypeMeta() error {
+	if w.APIVersion != SchemeGroupVersion.Identifier() || w.Kind != "Workload" {
+		return errWorkloadTypeMeta()
 	}
 	w.APIVersion = ""
 	w.Kind = ""
 	return nil
 }
 
+func errWorkloadTypeMeta() error {
+	return fmt.Errorf("file must contain resource with API Version %q and Kind %q", SchemeGroupVersion.Identifier(), "Workload")
+}
+
 func (w *Workload) loadAndValidateDocuments(in io.Reader) error {
 	d := yaml.NewYAMLOrJSONDecoder(in, 4096)
 	documents := 0
@@ -128,6 +128,93 @@ func TestWorkload_Load(t *testing.T) {
 	}
 }
 
+func TestLoadWorkloads(t *testing.T) {
+	workload := func(name string) *Workload {
+		return &Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"app.kubernetes.io/part-of":           name,
+					"apps.tanzu.vmware.com/workload-type": "web",
+				},
+			},
+			Spec: WorkloadSpec{
+				Source: &Source{
+					Git: &GitSource{
+						URL: "https://github.com/spring-projects/spring-petclinic.git",
+						Ref: GitRef{
+							Branch: "main",
+						},
+					},
+				},
+				Env: []corev1.EnvVar{
+					{
+						Name:  "SPRING_PROFILES_ACTIVE",
+						Value: "mysql",
+					},
+				},
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		file      string
+		want      []*Workload
+		shouldErr bool
+	}{{
+		name: "loads workload",
+		file: "testdata/workload.yaml",
+		want: []*Workload{workload("spring-petclinic")},
+	}, {
+		name: "multi document",
+		file: "testdata/multidocument.yaml",
+		want: []*Workload{workload("spring-petclinic0"), workload("spring-petclinic1"), workload("spring-petclinic2")},
+	}, {
+		name: "loads workload with first and last document empty",
+		file: "testdata/multidocument_first_last_empty.yaml",
+		want: []*Workload{workload("spring-petclinic")},
+	}, {
+		name:      "not a workload",
+		file:      "testdata/supplychain.yaml",
+		shouldErr: true,
+	}, {
+		name:      "malformed",
+		file:      "testdata/malformed.yaml",
+		shouldErr: true,
+	}, {
+		name:      "missing",
+		file:      "testdata/missing.yaml",
+		shouldErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, _ := os.Open(test.file)
+			defer f.Close()
+
+			got, err := LoadWorkloads(f)
+
+			if (err == nil) == test.shouldErr {
+				t.Errorf("LoadWorkloads() shouldErr %t %v", test.shouldErr, err)
+			} else if test.shouldErr {
+				return
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("LoadWorkloads() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestWorkload_MergeServiceAccountName(t *testing.T) {
 	serviceAccount := "test-service-account"
 	updatedServiceAccount := "updated-service-account"
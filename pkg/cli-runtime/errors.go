@@ -16,6 +16,10 @@ limitations under the License.
 
 package cli
 
+import (
+	"errors"
+)
+
 var SilentError = &silentError{}
 
 type silentError struct {
@@ -38,3 +42,37 @@ func (e *silentError) Is(err error) bool {
 func SilenceError(err error) error {
 	return &silentError{err: err}
 }
+
+var ExitCodeError = &exitCodeError{}
+
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+func (e *exitCodeError) Is(err error) bool {
+	_, ok := err.(*exitCodeError)
+	return ok
+}
+
+// WithExitCode sets the code the process exits with when the error is returned by a command.
+func WithExitCode(code int, err error) error {
+	return &exitCodeError{code: code, err: err}
+}
+
+// ExitCode returns the code the process exits with for the error, 1 unless set with WithExitCode.
+func ExitCode(err error) int {
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return 1
+}
@@ -41,3 +41,30 @@ func TestSilenceError(t *testing.T) {
 		t.Errorf("errors expected to match, expected %q, actually %q", expected, actual)
 	}
 }
+
+func TestWithExitCode(t *testing.T) {
+	err := fmt.Errorf("test error")
+	exitErr := cli.WithExitCode(2, err)
+
+	if errors.Is(err, cli.ExitCodeError) {
+		t.Errorf("expected error to not have an exit code, got %#v", err)
+	}
+	if !errors.Is(exitErr, cli.ExitCodeError) {
+		t.Errorf("expected error to have an exit code, got %#v", exitErr)
+	}
+	if expected, actual := err, errors.Unwrap(exitErr); expected != actual {
+		t.Errorf("errors expected to match, expected %v, actually %v", expected, actual)
+	}
+	if expected, actual := err.Error(), exitErr.Error(); expected != actual {
+		t.Errorf("errors expected to match, expected %q, actually %q", expected, actual)
+	}
+	if expected, actual := 1, cli.ExitCode(err); expected != actual {
+		t.Errorf("exit codes expected to match, expected %d, actually %d", expected, actual)
+	}
+	if expected, actual := 2, cli.ExitCode(exitErr); expected != actual {
+		t.Errorf("exit codes expected to match, expected %d, actually %d", expected, actual)
+	}
+	if expected, actual := 2, cli.ExitCode(cli.SilenceError(exitErr)); expected != actual {
+		t.Errorf("exit codes expected to match, expected %d, actually %d", expected, actual)
+	}
+}
@@ -32,8 +32,8 @@ type FakeTailer struct {
 	mock.Mock
 }
 
-func (f *FakeTailer) Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool) error {
-	args := f.Called(ctx, namespace, selector, containers, since, timestamps)
+func (f *FakeTailer) Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool, output string, opts TailOptions) error {
+	args := f.Called(ctx, namespace, selector, containers, since, timestamps, output, opts)
 	c.Printf("...tail output...\n")
 	if err := args.Error(0); err != nil {
 		return err
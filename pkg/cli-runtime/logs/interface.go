@@ -27,15 +27,29 @@ import (
 )
 
 type Tailer interface {
-	Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool) error
+	Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool, output string, opts TailOptions) error
 }
 
-func Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool) error {
+// TailOptions narrows down the log lines that are streamed. The zero value streams every line.
+type TailOptions struct {
+	// Include only prints the log lines matching at least one of the regular expressions
+	Include []string
+	// Exclude skips the log lines matching any of the regular expressions
+	Exclude []string
+	// MaxLogRequests is the maximum number of containers streamed at the same time, zero for no limit
+	MaxLogRequests int
+	// TailLines is the number of recent lines shown for each container, nil for all lines
+	TailLines *int64
+}
+
+// Tail streams the logs of the containers in the pods matching the selector. The logs are printed
+// as colored text, or as one JSON object per line when the output is "json".
+func Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool, output string, opts TailOptions) error {
 	tailer := RetrieveTailer(ctx)
 	if tailer == nil {
 		return fmt.Errorf("unable to retrieve tailer from the context: set the tailer on context with StashTailer(ctx context.Context, tailer Tailer) context.Context")
 	}
-	return tailer.Tail(ctx, c, namespace, selector, containers, since, timestamps)
+	return tailer.Tail(ctx, c, namespace, selector, containers, since, timestamps, output, opts)
 }
 
 var tailerStashKey = struct{}{}
@@ -22,22 +22,29 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/stern/stern/kubernetes"
 	"github.com/stern/stern/stern"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
 )
 
 var _ Tailer = &SternTailer{}
 
 type SternTailer struct{}
 
-func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool) error {
+func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string, selector labels.Selector, containers []string, since time.Duration, timestamps bool, output string, opts TailOptions) error {
 	containerQuery := regexp.MustCompile(".*")
 	if len(containers) != 0 {
 		escapedContainers := []string{}
@@ -47,6 +54,14 @@ func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string,
 		containerQuery = regexp.MustCompile(fmt.Sprintf("^(%s)$", strings.Join(escapedContainers, "|")))
 	}
 	t := "{{color .ContainerColor .PodName}}{{color .PodColor \"[\"}}{{color .PodColor .ContainerName}}{{color .PodColor \"]\"}} {{.Message}}\n"
+	location := time.Local
+	if output == printer.OutputFormatJson {
+		// the timestamp is always requested so it can be split from the message into its own field
+		t = "{{record .}}\n"
+		timestamps = true
+		location = time.UTC
+	}
+	components := &podComponents{ctx: ctx, c: c, cache: map[string]*podComponent{}}
 	funs := map[string]interface{}{
 		"json": func(in interface{}) (string, error) {
 			b, err := json.Marshal(in)
@@ -58,18 +73,33 @@ func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string,
 		"color": func(color color.Color, text string) string {
 			return color.SprintFunc()(text)
 		},
+		"record": func(log stern.Log) (string, error) {
+			b, err := json.Marshal(newLogRecord(log, components.lookup(log.Namespace, log.PodName)))
+			if err != nil {
+				return "", err
+			}
+			return string(b), nil
+		},
 	}
 	template, err := template.New("log").Funcs(funs).Parse(t)
 	if err != nil {
 		panic(err)
 	}
+	include, err := compileRegexps(opts.Include)
+	if err != nil {
+		return err
+	}
+	exclude, err := compileRegexps(opts.Exclude)
+	if err != nil {
+		return err
+	}
 
 	configStern := stern.Config{
 		KubeConfig:     c.KubeConfigFile,
 		ContextName:    c.CurrentContext,
 		Namespaces:     []string{namespace},
 		Timestamps:     timestamps,
-		Location:       time.Local,
+		Location:       location,
 		LabelSelector:  selector,
 		ContainerQuery: containerQuery,
 		ContainerStates: []stern.ContainerState{
@@ -78,6 +108,9 @@ func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string,
 		},
 		InitContainers: true,
 		Since:          since,
+		Include:        include,
+		Exclude:        exclude,
+		TailLines:      opts.TailLines,
 
 		// PodQuery and FieldSelector are required, but we use LabelSelector instead
 		PodQuery:      regexp.MustCompile(""),
@@ -88,5 +121,181 @@ func (s *SternTailer) Tail(ctx context.Context, c *cli.Config, namespace string,
 		ErrOut:   c.Stderr,
 	}
 
-	return stern.Run(ctx, &configStern)
+	if opts.MaxLogRequests == 0 {
+		return stern.Run(ctx, &configStern)
+	}
+	return run(ctx, &configStern, opts.MaxLogRequests)
+}
+
+// run is stern.Run for a single namespace that fails once more than maxLogRequests containers
+// would be streamed at the same time. Stern does not limit the number of log requests, run is
+// copied from stern.Run of github.com/stern/stern v1.21.0 and must follow it when stern is updated.
+func run(ctx context.Context, config *stern.Config, maxLogRequests int) error {
+	cc, err := kubernetes.NewClientConfig(config.KubeConfig, config.ContextName).ClientConfig()
+	if err != nil {
+		return err
+	}
+	clientset, err := corev1client.NewForConfig(cc)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	added, removed, err := stern.Watch(ctx,
+		clientset.Pods(config.Namespaces[0]),
+		config.PodQuery,
+		config.ExcludePodQuery,
+		config.ContainerQuery,
+		config.ExcludeContainerQuery,
+		config.InitContainers,
+		config.EphemeralContainers,
+		config.ContainerStates,
+		config.LabelSelector,
+		config.FieldSelector)
+	if err != nil {
+		return fmt.Errorf("failed to set up watch: %w", err)
+	}
+
+	tails := map[string]*stern.Tail{}
+	// tails of terminated containers are kept until their pod is removed, like stern does
+	finished := map[string]bool{}
+	done := make(chan *stern.Tail)
+	for {
+		select {
+		case target, ok := <-added:
+			if !ok {
+				return fmt.Errorf("lost watch connection")
+			}
+			id := target.GetID()
+			if tail, ok := tails[id]; ok {
+				if !finished[id] {
+					continue
+				}
+				tail.Close()
+				delete(tails, id)
+				delete(finished, id)
+			}
+			if maxLogRequests > 0 && len(tails)-len(finished) >= maxLogRequests {
+				return fmt.Errorf("reached the maximum number of concurrent log requests (%d) while adding %s/%s", maxLogRequests, target.Pod, target.Container)
+			}
+			tail := stern.NewTail(clientset, target.Node, target.Namespace, target.Pod, target.Container, config.Template, config.Out, config.ErrOut, &stern.TailOptions{
+				Timestamps:   config.Timestamps,
+				Location:     config.Location,
+				SinceSeconds: int64(config.Since.Seconds()),
+				Exclude:      config.Exclude,
+				Include:      config.Include,
+				TailLines:    config.TailLines,
+				Follow:       config.Follow,
+			})
+			tails[id] = tail
+			go func() {
+				if err := tail.Start(ctx); err != nil {
+					fmt.Fprintf(config.ErrOut, "unexpected error: %v\n", err)
+				}
+				select {
+				case done <- tail:
+				case <-ctx.Done():
+				}
+			}()
+		case target, ok := <-removed:
+			if !ok {
+				return fmt.Errorf("lost watch connection")
+			}
+			id := target.GetID()
+			if tail, ok := tails[id]; ok {
+				tail.Close()
+				delete(tails, id)
+				delete(finished, id)
+			}
+		case tail := <-done:
+			// a closed tail may have been replaced by a new tail for the same container
+			for id := range tails {
+				if tails[id] == tail {
+					finished[id] = true
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func compileRegexps(patterns []string) ([]*regexp.Regexp, error) {
+	regexps := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		regexps = append(regexps, r)
+	}
+	return regexps, nil
+}
+
+// LogRecord is a log line printed as JSON
+type LogRecord struct {
+	Timestamp string `json:"timestamp"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Component string `json:"component,omitempty"`
+	Message   string `json:"message"`
+}
+
+// newLogRecord creates a record from a log line, the message of the log line is prefixed by its
+// timestamp
+func newLogRecord(log stern.Log, component string) LogRecord {
+	record := LogRecord{
+		Namespace: log.Namespace,
+		Pod:       log.PodName,
+		Container: log.ContainerName,
+		Component: component,
+		Message:   log.Message,
+	}
+	if i := strings.IndexRune(log.Message, ' '); i != -1 {
+		record.Timestamp = log.Message[:i]
+		record.Message = log.Message[i+1:]
+	}
+	return record
+}
+
+// podComponents caches the component label of the pods being tailed, logs lines for many pods are
+// printed concurrently
+type podComponents struct {
+	ctx   context.Context
+	c     *cli.Config
+	m     sync.Mutex
+	cache map[string]*podComponent
+}
+
+// podComponent is the component of a pod, looked up once
+type podComponent struct {
+	once      sync.Once
+	component string
+}
+
+// lookup returns the component of the pod. Only the first lookup of a pod gets the pod, concurrent
+// lookups of the same pod wait for it without blocking the lookups of other pods. Pods that are not
+// found are cached as well, their component is omitted.
+func (p *podComponents) lookup(namespace, name string) string {
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	p.m.Lock()
+	entry, ok := p.cache[key]
+	if !ok {
+		entry = &podComponent{}
+		p.cache[key] = entry
+	}
+	p.m.Unlock()
+
+	entry.once.Do(func() {
+		pod := &corev1.Pod{}
+		if err := p.c.Get(p.ctx, client.ObjectKey{Namespace: namespace, Name: name}, pod); err != nil {
+			// the pod may have been deleted, the component is omitted
+			return
+		}
+		entry.component = pod.Labels[apis.ComponentLabelName]
+	})
+	return entry.component
 }
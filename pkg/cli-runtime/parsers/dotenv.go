@@ -0,0 +1,139 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DotEnvVar is a variable of a dotenv file, a deleted variable has no value
+type DotEnvVar struct {
+	Line   int
+	Name   string
+	Value  string
+	Delete bool
+}
+
+// DotEnv parses the variables of a dotenv file, the content is expected to be valid. Lines that
+// are not valid are skipped.
+func DotEnv(content string) []DotEnvVar {
+	vars := []DotEnvVar{}
+	for i, line := range strings.Split(content, "\n") {
+		v, err := DotEnvLine(line)
+		if err != nil || v.Name == "" {
+			continue
+		}
+		v.Line = i + 1
+		vars = append(vars, v)
+	}
+	return vars
+}
+
+// DotEnvLine parses a single line of a dotenv file. Blank lines and comments result in a variable
+// without a name. A variable is set with KEY=value, optionally prefixed by "export", and deleted
+// with KEY-. Values may be single quoted, taken literally, or double quoted, where \n, \", and \\
+// are unescaped. Unquoted values end at an inline comment.
+func DotEnvLine(line string) (DotEnvVar, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return DotEnvVar{}, nil
+	}
+	if rest := strings.TrimPrefix(line, "export"); rest != line && (strings.HasPrefix(rest, " ") || strings.HasPrefix(rest, "\t")) {
+		line = strings.TrimSpace(rest)
+	}
+
+	parts := strings.SplitN(line, "=", 2)
+	if len(parts) != 2 {
+		if strings.HasSuffix(line, "-") && !strings.ContainsAny(line, " \t") && len(line) > 1 {
+			return DotEnvVar{Name: line[:len(line)-1], Delete: true}, nil
+		}
+		return DotEnvVar{}, fmt.Errorf("expected KEY=value or KEY-")
+	}
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		return DotEnvVar{}, fmt.Errorf("missing variable name")
+	}
+	if strings.ContainsAny(name, " \t") {
+		return DotEnvVar{}, fmt.Errorf("variable name %q must not contain whitespace", name)
+	}
+
+	value, err := dotEnvValue(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return DotEnvVar{}, err
+	}
+	return DotEnvVar{Name: name, Value: value}, nil
+}
+
+func dotEnvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	switch raw[0] {
+	case '\'':
+		end := strings.IndexRune(raw[1:], '\'')
+		if end == -1 {
+			return "", fmt.Errorf("unterminated single quoted value")
+		}
+		if err := dotEnvTrailer(raw[end+2:]); err != nil {
+			return "", err
+		}
+		return raw[1 : end+1], nil
+	case '"':
+		value := strings.Builder{}
+		for i := 1; i < len(raw); i++ {
+			switch c := raw[i]; c {
+			case '\\':
+				if i+1 == len(raw) {
+					return "", fmt.Errorf("unterminated double quoted value")
+				}
+				i++
+				switch raw[i] {
+				case 'n':
+					value.WriteByte('\n')
+				case '"', '\\':
+					value.WriteByte(raw[i])
+				default:
+					value.WriteByte('\\')
+					value.WriteByte(raw[i])
+				}
+			case '"':
+				if err := dotEnvTrailer(raw[i+1:]); err != nil {
+					return "", err
+				}
+				return value.String(), nil
+			default:
+				value.WriteByte(c)
+			}
+		}
+		return "", fmt.Errorf("unterminated double quoted value")
+	default:
+		if i := strings.Index(raw, " #"); i != -1 {
+			raw = raw[:i]
+		}
+		return strings.TrimSpace(raw), nil
+	}
+}
+
+// dotEnvTrailer checks that only a comment follows a quoted value
+func dotEnvTrailer(trailer string) error {
+	trailer = strings.TrimSpace(trailer)
+	if trailer != "" && !strings.HasPrefix(trailer, "#") {
+		return fmt.Errorf("unexpected %q after quoted value", trailer)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parsers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/parsers"
+)
+
+func TestDotEnvLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    parsers.DotEnvVar
+		expectedErr error
+	}{{
+		name:     "blank",
+		value:    "  ",
+		expected: parsers.DotEnvVar{},
+	}, {
+		name:     "comment",
+		value:    "# FOO=bar",
+		expected: parsers.DotEnvVar{},
+	}, {
+		name:     "unquoted",
+		value:    "FOO=bar baz",
+		expected: parsers.DotEnvVar{Name: "FOO", Value: "bar baz"},
+	}, {
+		name:     "unquoted with comment",
+		value:    "FOO=bar # the bar",
+		expected: parsers.DotEnvVar{Name: "FOO", Value: "bar"},
+	}, {
+		name:     "unquoted with hash",
+		value:    "FOO=bar#baz",
+		expected: parsers.DotEnvVar{Name: "FOO", Value: "bar#baz"},
+	}, {
+		name:     "empty value",
+		value:    "FOO=",
+		expected: parsers.DotEnvVar{Name: "FOO"},
+	}, {
+		name:     "spaces around the equal sign",
+		value:    " FOO = bar ",
+		expected: parsers.DotEnvVar{Name: "FOO", Value: "bar"},
+	}, {
+		name:     "export",
+		value:    "export FOO=bar",
+		expected: parsers.DotEnvVar{Name: "FOO", Value: "bar"},
+	}, {
+		name:     "export as name",
+		value:    "export=bar",
+		expected: parsers.DotEnvVar{Name: "export", Value: "bar"},
+	}, {
+		name:     "single quoted",
+		value:    `FOO='bar \n "baz" # qux'`,
+		expected: parsers.DotEnvVar{Name: "FOO", Value: `bar \n "baz" # qux`},
+	}, {
+		name:     "double quoted",
+		value:    `FOO="bar\n\"baz\" \\ 'qux' \t" # comment`,
+		expected: parsers.DotEnvVar{Name: "FOO", Value: "bar\n\"baz\" \\ 'qux' \\t"},
+	}, {
+		name:     "delete",
+		value:    "FOO-",
+		expected: parsers.DotEnvVar{Name: "FOO", Delete: true},
+	}, {
+		name:     "export delete",
+		value:    "export FOO-",
+		expected: parsers.DotEnvVar{Name: "FOO", Delete: true},
+	}, {
+		name:        "missing value",
+		value:       "FOO",
+		expectedErr: fmt.Errorf("expected KEY=value or KEY-"),
+	}, {
+		name:        "missing name",
+		value:       "=bar",
+		expectedErr: fmt.Errorf("missing variable name"),
+	}, {
+		name:        "name with whitespace",
+		value:       "FOO BAR=baz",
+		expectedErr: fmt.Errorf("variable name \"FOO BAR\" must not contain whitespace"),
+	}, {
+		name:        "unterminated single quote",
+		value:       "FOO='bar",
+		expectedErr: fmt.Errorf("unterminated single quoted value"),
+	}, {
+		name:        "unterminated double quote",
+		value:       `FOO="bar\"`,
+		expectedErr: fmt.Errorf("unterminated double quoted value"),
+	}, {
+		name:        "text after quoted value",
+		value:       `FOO="bar" baz`,
+		expectedErr: fmt.Errorf("unexpected \"baz\" after quoted value"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := parsers.DotEnvLine(test.value)
+			if fmt.Sprint(test.expectedErr) != fmt.Sprint(err) {
+				t.Errorf("%s() error = %v, expected %v", test.name, err, test.expectedErr)
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestDotEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []parsers.DotEnvVar
+	}{{
+		name:     "empty",
+		value:    "",
+		expected: []parsers.DotEnvVar{},
+	}, {
+		name:  "variables",
+		value: "# database\r\nexport DB_HOST=localhost\r\nDB_PASSWORD='s3cr3t'\r\n\r\nDEBUG-\r\n",
+		expected: []parsers.DotEnvVar{
+			{Line: 2, Name: "DB_HOST", Value: "localhost"},
+			{Line: 3, Name: "DB_PASSWORD", Value: "s3cr3t"},
+			{Line: 5, Name: "DEBUG", Delete: true},
+		},
+	}, {
+		name:  "invalid lines are skipped",
+		value: "FOO\nBAR=baz",
+		expected: []parsers.DotEnvVar{
+			{Line: 2, Name: "BAR", Value: "baz"},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := parsers.DotEnv(test.value)
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package parsers
 
 import (
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -62,6 +63,34 @@ func EnvVarFrom(str string) corev1.EnvVar {
 	return envvar
 }
 
+func EnvVarFromSecret(str string) corev1.EnvVar {
+	return EnvVarFrom(SecretKeyRefEnvVar(str))
+}
+
+func EnvVarFromConfigMap(str string) corev1.EnvVar {
+	return EnvVarFrom(ConfigMapKeyRefEnvVar(str))
+}
+
+// SecretKeyRefEnvVar rewrites NAME=secret:key to the NAME=secretKeyRef:secret:key form read by
+// EnvVarFrom. Values without a name are returned as is.
+func SecretKeyRefEnvVar(str string) string {
+	return keyRefEnvVar("secretKeyRef", str)
+}
+
+// ConfigMapKeyRefEnvVar rewrites NAME=configmap:key to the NAME=configMapKeyRef:configmap:key form
+// read by EnvVarFrom. Values without a name are returned as is.
+func ConfigMapKeyRefEnvVar(str string) string {
+	return keyRefEnvVar("configMapKeyRef", str)
+}
+
+func keyRefEnvVar(source, str string) string {
+	parts := KeyValue(str)
+	if len(parts) != 2 {
+		return str
+	}
+	return fmt.Sprintf("%s=%s:%s", parts[0], source, parts[1])
+}
+
 func DeletableEnvVar(str string) (corev1.EnvVar, bool) {
 	parts := DeletableKeyValue(str)
 	if len(parts) == 2 {
@@ -96,6 +96,104 @@ func TestEnvVarFrom(t *testing.T) {
 	}
 }
 
+func TestEnvVarFromSecret(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected corev1.EnvVar
+		value    string
+	}{{
+		name:  "valid",
+		value: "MY_VAR=my-secret:my-key",
+		expected: corev1.EnvVar{
+			Name: "MY_VAR",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "my-secret",
+					},
+					Key: "my-key",
+				},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := parsers.EnvVarFromSecret(test.value)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEnvVarFromConfigMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected corev1.EnvVar
+		value    string
+	}{{
+		name:  "valid",
+		value: "MY_VAR=my-configmap:my-key",
+		expected: corev1.EnvVar{
+			Name: "MY_VAR",
+			ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "my-configmap",
+					},
+					Key: "my-key",
+				},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := parsers.EnvVarFromConfigMap(test.value)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestKeyRefEnvVar(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		value    string
+		keyRef   func(string) string
+	}{{
+		name:     "secret",
+		value:    "MY_VAR=my-secret:my-key",
+		keyRef:   parsers.SecretKeyRefEnvVar,
+		expected: "MY_VAR=secretKeyRef:my-secret:my-key",
+	}, {
+		name:     "configmap",
+		value:    "MY_VAR=my-configmap:my-key",
+		keyRef:   parsers.ConfigMapKeyRefEnvVar,
+		expected: "MY_VAR=configMapKeyRef:my-configmap:my-key",
+	}, {
+		name:     "missing name",
+		value:    "my-secret:my-key",
+		keyRef:   parsers.SecretKeyRefEnvVar,
+		expected: "my-secret:my-key",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := test.keyRef(test.value)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
 func TestDeletableEnvVar(t *testing.T) {
 	type res struct {
 		Env    corev1.EnvVar
@@ -17,6 +17,8 @@ limitations under the License.
 package testing
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -54,3 +56,13 @@ type fakeclient struct {
 	defaultNamespace string
 	crclient.Client
 }
+
+// Patch ignores the server-side apply patches accepted by the IgnoreApplyPatches reactor, the
+// patches are captured but the fake client is not able to apply them.
+func (c *fakeclient) Patch(ctx context.Context, obj crclient.Object, patch crclient.Patch, opts ...crclient.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	if errors.Is(err, errApplyPatchIgnored) {
+		return nil
+	}
+	return err
+}
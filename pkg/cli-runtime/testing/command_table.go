@@ -32,6 +32,7 @@ import (
 	rtesting "github.com/vmware-labs/reconciler-runtime/testing"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -46,8 +47,8 @@ import (
 // if the object does not exist and create operations will error if the resource does exist).
 //
 // ExpectCreates and ExpectUpdates each contain objects that are compared directly to resources
-// received by the client. ExpectDeletes and ExpectDeleteCollections contain references to the
-// resources impacted by the call since these calls do not receive an object.
+// received by the client. ExpectPatches, ExpectDeletes and ExpectDeleteCollections contain
+// references to the resources impacted by the call since these calls do not receive a full object.
 //
 // Errors can be injected into API calls by reactor functions specified in WithReactors. A
 // ReactionFunc is able to intercept each client operation to observe or mutate the request or
@@ -126,6 +127,10 @@ type CommandTestCase struct {
 	// ExpectUpdates asserts each resource with the resources passed to the Update method of the
 	// fake client in order.
 	ExpectUpdates []client.Object
+	// ExpectPatches assert references to the Patch method of the fake client in order, along with
+	// the type and content of the patch. The fake client does not support server-side apply
+	// patches, use the IgnoreApplyPatches reactor to accept them without changing the resources.
+	ExpectPatches []PatchRef
 	// ExpectDeletes assert references to the Delete method of the fake client in order.
 	// Unlike Create and Update, Delete does not receive a full resource, so a reference is used
 	// instead. The Group will be blank for 'core' resources. The Resource is not a Kind, but
@@ -278,6 +283,22 @@ func (tc CommandTestCase) Run(t *testing.T, scheme *runtime.Scheme, cmdFactory f
 		rtesting.CompareActions(t, "create", tc.ExpectCreates, client.CreateActions, rtesting.IgnoreLastTransitionTime, rtesting.SafeDeployDiff, rtesting.IgnoreTypeMeta, rtesting.IgnoreResourceVersion, cmpopts.EquateEmpty())
 		rtesting.CompareActions(t, "update", tc.ExpectUpdates, client.UpdateActions, rtesting.IgnoreLastTransitionTime, rtesting.SafeDeployDiff, rtesting.IgnoreTypeMeta, rtesting.IgnoreResourceVersion, cmpopts.EquateEmpty())
 
+		for i, expected := range tc.ExpectPatches {
+			if i >= len(client.PatchActions) {
+				t.Errorf("Missing patch: %#v", expected)
+				continue
+			}
+			actual := NewPatchRef(client.PatchActions[i])
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("Unexpected patch (-expected, +actual): %s", diff)
+			}
+		}
+		if actual, expected := len(client.PatchActions), len(tc.ExpectPatches); actual > expected {
+			for _, extra := range client.PatchActions[expected:] {
+				t.Errorf("Extra patch: %#v", extra)
+			}
+		}
+
 		for i, expected := range tc.ExpectDeletes {
 			if i >= len(client.DeleteActions) {
 				t.Errorf("Missing delete: %#v", expected)
@@ -329,10 +350,31 @@ func (tc CommandTestCase) Run(t *testing.T, scheme *runtime.Scheme, cmdFactory f
 }
 
 func objKey(o runtime.Object) string {
-	on := o.(metav1.ObjectMetaAccessor)
+	on := o.(metav1.Object)
 	// namespace + name is not unique, and the tests don't populate k8s kind
-	// information, so use GoLang's type name as part of the key.
-	return path.Join(reflect.TypeOf(o).String(), on.GetObjectMeta().GetNamespace(), on.GetObjectMeta().GetName())
+	// information, so use GoLang's type name as part of the key. Unstructured
+	// objects always carry their kind.
+	return path.Join(reflect.TypeOf(o).String(), o.GetObjectKind().GroupVersionKind().Kind, on.GetNamespace(), on.GetName())
+}
+
+type PatchRef struct {
+	Group     string
+	Resource  string
+	Namespace string
+	Name      string
+	PatchType types.PatchType
+	Patch     string
+}
+
+func NewPatchRef(action clientgotesting.PatchAction) PatchRef {
+	return PatchRef{
+		Group:     action.GetResource().Group,
+		Resource:  action.GetResource().Resource,
+		Namespace: action.GetNamespace(),
+		Name:      action.GetName(),
+		PatchType: action.GetPatchType(),
+		Patch:     string(action.GetPatch()),
+	}
 }
 
 type DeleteRef struct {
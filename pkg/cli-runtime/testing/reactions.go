@@ -18,8 +18,10 @@ package testing
 
 import (
 	"context"
+	"errors"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
@@ -48,3 +50,15 @@ func ValidateUpdates(ctx context.Context, action clientgotesting.Action) (handle
 	}
 	return false, nil, nil
 }
+
+var errApplyPatchIgnored = errors.New("server-side apply patch ignored")
+
+// IgnoreApplyPatches accepts server-side apply patches without applying them, the fake client does
+// not support server-side apply. The patches are still captured to be asserted with ExpectPatches.
+func IgnoreApplyPatches(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
+	patch, ok := action.(clientgotesting.PatchAction)
+	if !ok || patch.GetPatchType() != types.ApplyPatchType {
+		return false, nil, nil
+	}
+	return true, nil, errApplyPatchIgnored
+}
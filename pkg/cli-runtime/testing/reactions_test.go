@@ -21,8 +21,11 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
 	clitestingresource "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing/resource"
@@ -145,3 +148,64 @@ func TestValidateUpdates(t *testing.T) {
 		})
 	}
 }
+
+func TestIgnoreApplyPatches(t *testing.T) {
+	gvr := clitestingresource.SchemeBuilder.GroupVersion.WithResource("TestResource")
+	tests := []struct {
+		name    string
+		action  clientgotesting.Action
+		handled bool
+	}{{
+		name:    "apply patch",
+		action:  clientgotesting.NewPatchAction(gvr, "default", "my-resource", types.ApplyPatchType, []byte(`{}`)),
+		handled: true,
+	}, {
+		name:    "merge patch",
+		action:  clientgotesting.NewPatchAction(gvr, "default", "my-resource", types.MergePatchType, []byte(`{}`)),
+		handled: false,
+	}, {
+		name:    "create",
+		action:  clientgotesting.NewCreateAction(gvr, "default", &clitestingresource.TestResource{}),
+		handled: false,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handled, returned, err := clitesting.IgnoreApplyPatches(test.action)
+			if expected, actual := test.handled, handled; expected != actual {
+				t.Errorf("Expected handled %v, actually %v", expected, actual)
+			}
+			if returned != nil {
+				t.Errorf("Unexpected returned object %v", returned)
+			}
+			if (err != nil) != test.handled {
+				t.Errorf("Expected error %v, actually %q", test.handled, err)
+			}
+		})
+	}
+
+	t.Run("fake client", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		_ = clitestingresource.AddToScheme(scheme)
+		resource := &clitestingresource.TestResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-resource",
+			},
+		}
+
+		fakeClient := clitesting.NewFakeClient(scheme)
+		if err := clitesting.NewFakeCliClient(fakeClient).Patch(context.TODO(), resource.DeepCopy(), client.Apply); err == nil {
+			t.Errorf("Expected apply patch to error without the reactor")
+		}
+
+		fakeClient = clitesting.NewFakeClient(scheme)
+		fakeClient.AddReactor("patch", "*", clitesting.IgnoreApplyPatches)
+		if err := clitesting.NewFakeCliClient(fakeClient).Patch(context.TODO(), resource.DeepCopy(), client.Apply); err != nil {
+			t.Errorf("Expected apply patch to be ignored, actually %q", err)
+		}
+		if expected, actual := 1, len(fakeClient.PatchActions); expected != actual {
+			t.Errorf("Expected %d captured patch, actually %d", expected, actual)
+		}
+	})
+}
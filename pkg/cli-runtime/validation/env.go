@@ -17,7 +17,10 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"strings"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/parsers"
 )
 
 func EnvVar(env, field string) FieldErrors {
@@ -48,8 +51,10 @@ func EnvVarFrom(env, field string) FieldErrors {
 			errs = errs.Also(ErrInvalidValue(env, field))
 		} else if value[0] != "configMapKeyRef" && value[0] != "secretKeyRef" {
 			errs = errs.Also(ErrInvalidValue(env, field))
-		} else if value[1] == "" {
+		} else if value[1] == "" || value[2] == "" {
 			errs = errs.Also(ErrInvalidValue(env, field))
+		} else {
+			errs = errs.Also(K8sName(value[1], field))
 		}
 	}
 
@@ -65,3 +70,17 @@ func EnvVarFroms(envs []string, field string) FieldErrors {
 
 	return errs
 }
+
+// DotEnv validates each line of a dotenv file. The content of an invalid line is not reported,
+// dotenv files often hold credentials.
+func DotEnv(content, field string) FieldErrors {
+	errs := FieldErrors{}
+
+	for i, line := range strings.Split(content, "\n") {
+		if _, err := parsers.DotEnvLine(line); err != nil {
+			errs = errs.Also(ErrInvalidValueWithDetail(fmt.Sprintf("line %d", i+1), field, err.Error()))
+		}
+	}
+
+	return errs
+}
@@ -200,6 +200,14 @@ func TestEnvVarFrom(t *testing.T) {
 		name:     "missing key",
 		expected: validation.ErrInvalidValue("MY_VAR=configMapKeyRef:my-configmap", clitesting.TestField),
 		value:    "MY_VAR=configMapKeyRef:my-configmap",
+	}, {
+		name:     "empty key",
+		expected: validation.ErrInvalidValue("MY_VAR=secretKeyRef:my-secret:", clitesting.TestField),
+		value:    "MY_VAR=secretKeyRef:my-secret:",
+	}, {
+		name:     "invalid resource name",
+		expected: validation.ErrInvalidValue("My_Secret", clitesting.TestField),
+		value:    "MY_VAR=secretKeyRef:My_Secret:my-key",
 	}}
 
 	for _, test := range tests {
@@ -249,3 +257,36 @@ func TestEnvVarFroms(t *testing.T) {
 		})
 	}
 }
+
+func TestDotEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected validation.FieldErrors
+		value    string
+	}{{
+		name:     "valid, empty",
+		expected: validation.FieldErrors{},
+		value:    "",
+	}, {
+		name:     "valid",
+		expected: validation.FieldErrors{},
+		value:    "# comment\nFOO=bar\n\nexport BAR=\"baz\"\nBAZ-\n",
+	}, {
+		name: "invalid",
+		expected: validation.FieldErrors{}.Also(
+			validation.ErrInvalidValueWithDetail("line 2", clitesting.TestField, "expected KEY=value or KEY-"),
+			validation.ErrInvalidValueWithDetail("line 4", clitesting.TestField, "unterminated single quoted value"),
+		),
+		value: "# comment\nFOO\nBAZ=qux\nBAR='baz\n",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.DotEnv(test.value, clitesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
@@ -25,3 +25,9 @@ func ErrMissingFieldWithDetail(field string, detail string) FieldErrors {
 		k8sfield.Required(k8sfield.NewPath(field), detail),
 	}
 }
+
+func ErrInvalidValueWithDetail(value interface{}, field string, detail string) FieldErrors {
+	return FieldErrors{
+		k8sfield.Invalid(k8sfield.NewPath(field), value, detail),
+	}
+}
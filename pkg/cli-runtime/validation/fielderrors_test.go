@@ -56,3 +56,37 @@ func TestErrMissingFieldWithDetail(t *testing.T) {
 		})
 	}
 }
+
+func TestErrInvalidValueWithDetail(t *testing.T) {
+	tests := []struct {
+		testName string
+		value    string
+		field    string
+		msg      string
+		expected validation.FieldErrors
+	}{
+		{
+			testName: "valid",
+			expected: validation.FieldErrors{k8sfield.Invalid(k8sfield.NewPath(flags.EnvFileFlagName), "FOO", "")},
+			value:    "FOO",
+			field:    flags.EnvFileFlagName,
+			msg:      "",
+		}, {
+			testName: "valid with msg",
+			expected: validation.FieldErrors{k8sfield.Invalid(k8sfield.NewPath(flags.EnvFileFlagName), "line 3", "expected KEY=value or KEY-")},
+			value:    "line 3",
+			field:    flags.EnvFileFlagName,
+			msg:      "expected KEY=value or KEY-",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.ErrInvalidValueWithDetail(test.value, test.field, test.msg)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.testName, diff)
+			}
+		})
+	}
+}
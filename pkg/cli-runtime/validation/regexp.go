@@ -0,0 +1,41 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"regexp"
+)
+
+func Regexp(pattern, field string) FieldErrors {
+	errs := FieldErrors{}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		errs = errs.Also(ErrInvalidValue(pattern, field))
+	}
+
+	return errs
+}
+
+func Regexps(patterns []string, field string) FieldErrors {
+	errs := FieldErrors{}
+
+	for i, pattern := range patterns {
+		errs = errs.Also(Regexp(pattern, CurrentField).ViaFieldIndex(field, i))
+	}
+
+	return errs
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+)
+
+func TestRegexp(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected validation.FieldErrors
+		value    string
+	}{{
+		name:     "valid",
+		expected: validation.FieldErrors{},
+		value:    "ERROR|WARN",
+	}, {
+		name:     "empty",
+		expected: validation.FieldErrors{},
+		value:    "",
+	}, {
+		name:     "invalid",
+		expected: validation.ErrInvalidValue("[a-", clitesting.TestField),
+		value:    "[a-",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.Regexp(test.value, clitesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestRegexps(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected validation.FieldErrors
+		values   []string
+	}{{
+		name:     "valid, empty",
+		expected: validation.FieldErrors{},
+		values:   []string{},
+	}, {
+		name:     "valid, not empty",
+		expected: validation.FieldErrors{},
+		values:   []string{"ERROR", "^\\d+"},
+	}, {
+		name:     "invalid",
+		expected: validation.ErrInvalidValue("(", validation.CurrentField).ViaFieldIndex(clitesting.TestField, 1),
+		values:   []string{"ERROR", "("},
+	}, {
+		name: "multiple invalid",
+		expected: validation.FieldErrors{}.Also(
+			validation.ErrInvalidValue("(", validation.CurrentField).ViaFieldIndex(clitesting.TestField, 0),
+			validation.ErrInvalidValue("[a-", validation.CurrentField).ViaFieldIndex(clitesting.TestField, 1),
+		),
+		values: []string{"(", "[a-"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			actual := validation.Regexps(test.values, clitesting.TestField)
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s() = (-expected, +actual): %s", test.name, diff)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ package fake
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -62,3 +64,42 @@ func (c *FakeWithWatch) Watch(ctx context.Context, list client.ObjectList, opts
 	}()
 	return watcher, nil
 }
+
+var _ client.WithWatch = &FakeWithRestartErr{}
+
+// FakeWithRestartErr closes the first watch of each list type right away, watching the same list
+// type again fails. When list types are given, the watches of other list types stay open.
+type FakeWithRestartErr struct {
+	client.Client
+	m       sync.Mutex
+	watched map[reflect.Type]bool
+	failing map[reflect.Type]bool
+}
+
+func NewFakeWithRestartErr(client client.Client, lists ...client.ObjectList) *FakeWithRestartErr {
+	failing := map[reflect.Type]bool{}
+	for _, list := range lists {
+		failing[reflect.TypeOf(list)] = true
+	}
+	return &FakeWithRestartErr{
+		Client:  client,
+		watched: map[reflect.Type]bool{},
+		failing: failing,
+	}
+}
+
+func (c *FakeWithRestartErr) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	t := reflect.TypeOf(list)
+	if len(c.failing) != 0 && !c.failing[t] {
+		return watch.NewFake(), nil
+	}
+	if c.watched[t] {
+		return nil, fmt.Errorf("failed to restart watcher")
+	}
+	c.watched[t] = true
+	watcher := watch.NewFake()
+	watcher.Stop()
+	return watcher, nil
+}
@@ -17,33 +17,69 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"os/user"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	units "github.com/docker/go-units"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/parsers"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/wait"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/watch"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/source"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/template"
 )
 
 const AnnotationReservedKey = "annotations"
 
+// environment variables with the registry credentials, used when the registry flags are not set
+const (
+	RegistryUsernameEnv = "TANZU_APPS_REGISTRY_USERNAME"
+	RegistryPasswordEnv = "TANZU_APPS_REGISTRY_PASSWORD"
+)
+
+// WorkloadFieldManager is the field manager used for the workloads written by the cli
+const WorkloadFieldManager = "tanzu-apps-cli"
+
+// retryOnConflictBackoff delays each retry of an update that conflicts with a concurrent change to
+// the workload, long enough to outlast a burst of writes by controllers
+var retryOnConflictBackoff = k8swait.Backoff{
+	Duration: 100 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    6,
+	Cap:      2 * time.Second,
+}
+
 func NewWorkloadCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "workload",
@@ -63,9 +99,12 @@ Workload configuration includes:
 	cmd.AddCommand(NewWorkloadListCommand(ctx, c))
 	cmd.AddCommand(NewWorkloadGetCommand(ctx, c))
 	cmd.AddCommand(NewWorkloadTailCommand(ctx, c))
+	cmd.AddCommand(NewWorkloadEventsCommand(ctx, c))
+	cmd.AddCommand(NewWorkloadEnvCommand(ctx, c))
 	cmd.AddCommand(NewWorkloadCreateCommand(ctx, c))
 	cmd.AddCommand(NewWorkloadUpdateCommand(ctx, c))
 	cmd.AddCommand(NewWorkloadApplyCommand(ctx, c))
+	cmd.AddCommand(NewWorkloadDiffCommand(ctx, c))
 	cmd.AddCommand(NewWorkloadDeleteCommand(ctx, c))
 
 	return cmd
@@ -85,6 +124,10 @@ type WorkloadOptions struct {
 	LiveUpdate  bool
 
 	FilePath    string
+	Vars        []string
+	VarFile     string
+	Overlays    []string
+	NoConfig    bool
 	GitRepo     string
 	GitCommit   string
 	GitBranch   string
@@ -94,10 +137,38 @@ type WorkloadOptions struct {
 	Image       string
 	SubPath     string
 
+	// ProjectSourceImage is the source image of the project config, used when the workload has no
+	// other source
+	ProjectSourceImage string
+
+	SourceTarball    string
+	SourceOCILayout  string
+	SourceSigningKey string
+
+	RegistryCACerts     []string
+	RegistryVerifyCerts bool
+	RegistryUsername    string
+	RegistryPassword    string
+	RegistryConfig      string
+
 	BuildEnv    []string
 	Env         []string
 	ServiceRefs []string
 
+	EnvFile      string
+	BuildEnvFile string
+
+	// EnvFileVars, BuildEnvFileVars and VarFileVars are the variables of the dotenv files, the
+	// files are read once when the options are validated
+	EnvFileVars      []parsers.DotEnvVar
+	BuildEnvFileVars []parsers.DotEnvVar
+	VarFileVars      []parsers.DotEnvVar
+
+	EnvFromSecret         []string
+	EnvFromConfigMap      []string
+	BuildEnvFromSecret    []string
+	BuildEnvFromConfigMap []string
+
 	ServiceAccountName string
 
 	LimitCPU    string
@@ -110,8 +181,13 @@ type WorkloadOptions struct {
 	WaitTimeout    time.Duration
 	Tail           bool
 	TailTimestamps bool
+	TailOutput     string
 	DryRun         bool
 	Yes            bool
+
+	ServerSide      bool
+	ForceConflicts  bool
+	RetryOnConflict bool
 }
 
 var _ validation.Validatable = (*WorkloadUpdateOptions)(nil)
@@ -127,8 +203,31 @@ func (opts *WorkloadOptions) Validate(ctx context.Context) validation.FieldError
 	errs = errs.Also(validation.DeletableKeyValues(opts.Annotations, flags.AnnotationFlagName))
 	errs = errs.Also(validation.DeletableKeyValues(opts.Params, flags.ParamFlagName))
 	errs = errs.Also(validation.JsonOrYamlKeyValues(opts.ParamsYaml, flags.ParamYamlFlagName))
+	errs = errs.Also(validation.KeyValues(opts.Vars, flags.VarFlagName))
+	var fileErrs validation.FieldErrors
+	opts.VarFileVars, fileErrs = loadVarFile(opts.VarFile, flags.VarFileFlagName)
+	errs = errs.Also(fileErrs)
+	if (len(opts.Vars) != 0 || opts.VarFile != "") && opts.FilePath == "" {
+		errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.FilePathFlagName, fmt.Sprintf("%s and %s are only supported with a workload file", flags.VarFlagName, flags.VarFileFlagName)))
+	}
+	for _, overlay := range opts.Overlays {
+		if _, err := os.Stat(overlay); err != nil {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(overlay, flags.OverlayFlagName, err.Error()))
+		}
+	}
+	if len(opts.Overlays) != 0 && opts.FilePath == "" {
+		errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.FilePathFlagName, fmt.Sprintf("%s is only supported with a workload file", flags.OverlayFlagName)))
+	}
 	errs = errs.Also(validation.DeletableEnvVars(opts.Env, flags.EnvFlagName))
 	errs = errs.Also(validation.DeletableEnvVars(opts.BuildEnv, flags.BuildEnvFlagName))
+	opts.EnvFileVars, fileErrs = loadEnvFile(opts.EnvFile, flags.EnvFileFlagName)
+	errs = errs.Also(fileErrs)
+	opts.BuildEnvFileVars, fileErrs = loadEnvFile(opts.BuildEnvFile, flags.BuildEnvFileFlagName)
+	errs = errs.Also(fileErrs)
+	errs = errs.Also(validation.EnvVarFroms(envVarKeyRefs(opts.EnvFromSecret, parsers.SecretKeyRefEnvVar), flags.EnvFromSecretFlagName))
+	errs = errs.Also(validation.EnvVarFroms(envVarKeyRefs(opts.EnvFromConfigMap, parsers.ConfigMapKeyRefEnvVar), flags.EnvFromConfigMapFlagName))
+	errs = errs.Also(validation.EnvVarFroms(envVarKeyRefs(opts.BuildEnvFromSecret, parsers.SecretKeyRefEnvVar), flags.BuildEnvFromSecretFlagName))
+	errs = errs.Also(validation.EnvVarFroms(envVarKeyRefs(opts.BuildEnvFromConfigMap, parsers.ConfigMapKeyRefEnvVar), flags.BuildEnvFromConfigMapFlagName))
 	errs = errs.Also(validation.DeletableKeyObjectReferences(opts.ServiceRefs, flags.ServiceRefFlagName))
 
 	if opts.LimitCPU != "" {
@@ -167,9 +266,133 @@ func (opts *WorkloadOptions) Validate(ctx context.Context) validation.FieldError
 		errs = errs.Also(validation.ErrMultipleOneOf(source...))
 	}
 
+	if opts.ForceConflicts && !opts.ServerSide {
+		errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.ServerSideFlagName, fmt.Sprintf("%s is only supported with server-side apply", flags.ForceConflictsFlagName)))
+	}
+
+	if opts.SourceTarball != "" && opts.SourceOCILayout != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.SourceTarballFlagName, flags.SourceOCILayoutFlagName))
+	}
+	if opts.SourceTarball != "" && opts.LocalPath == "" {
+		errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.LocalPathFlagName, fmt.Sprintf("%s is only supported with local source", flags.SourceTarballFlagName)))
+	}
+	if opts.SourceOCILayout != "" && opts.LocalPath == "" {
+		errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.LocalPathFlagName, fmt.Sprintf("%s is only supported with local source", flags.SourceOCILayoutFlagName)))
+	}
+
+	if opts.SourceSigningKey != "" && opts.LocalPath == "" {
+		errs = errs.Also(validation.ErrMissingFieldWithDetail(flags.LocalPathFlagName, fmt.Sprintf("%s is only supported with local source", flags.SourceSigningKeyFlagName)))
+	}
+	if opts.SourceSigningKey != "" && opts.SourceTarball != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.SourceSigningKeyFlagName, flags.SourceTarballFlagName))
+	}
+	if opts.SourceSigningKey != "" && opts.SourceOCILayout != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.SourceSigningKeyFlagName, flags.SourceOCILayoutFlagName))
+	}
+
+	if opts.RegistryUsername != "" && opts.RegistryConfig != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.RegistryUsernameFlagName, flags.RegistryConfigFlagName))
+	}
+	if opts.RegistryPassword != "" && opts.RegistryUsername == "" {
+		errs = errs.Also(validation.ErrMissingField(flags.RegistryUsernameFlagName))
+	}
+
+	if opts.TailOutput != "" {
+		errs = errs.Also(validation.Enum(opts.TailOutput, flags.TailOutputFlagName, []string{printer.OutputFormatJson}))
+	}
+
 	return errs
 }
 
+// loadEnvFile reads and parses a dotenv file, the variables are only returned when the file is valid
+func loadEnvFile(path, field string) ([]parsers.DotEnvVar, validation.FieldErrors) {
+	if path == "" {
+		return nil, validation.FieldErrors{}
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, validation.ErrInvalidValueWithDetail(path, field, err.Error())
+	}
+	if errs := validation.DotEnv(string(content), field); len(errs) != 0 {
+		return nil, errs
+	}
+	return parsers.DotEnv(string(content)), validation.FieldErrors{}
+}
+
+// loadVarFile reads and parses a dotenv file of template variables, variables can not be deleted
+func loadVarFile(path, field string) ([]parsers.DotEnvVar, validation.FieldErrors) {
+	vars, errs := loadEnvFile(path, field)
+	for _, v := range vars {
+		if v.Delete {
+			errs = errs.Also(validation.ErrInvalidValueWithDetail(fmt.Sprintf("line %d", v.Line), field, "expected KEY=value"))
+		}
+	}
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	return vars, errs
+}
+
+// TemplateVars returns the variables resolving the placeholders of the workload file, nil when
+// the file is not a template. Variables set with --var take precedence over the var file.
+func (opts *WorkloadOptions) TemplateVars() map[string]string {
+	if len(opts.Vars) == 0 && opts.VarFile == "" {
+		return nil
+	}
+	vars := map[string]string{}
+	for _, v := range opts.VarFileVars {
+		vars[v.Name] = v.Value
+	}
+	for _, kv := range opts.Vars {
+		parts := parsers.KeyValue(kv)
+		vars[parts[0]] = parts[1]
+	}
+	return vars
+}
+
+// anyTail checks if logs are shown while waiting for the workload to become ready
+func (opts *WorkloadOptions) anyTail() bool {
+	return opts.Tail || opts.TailTimestamps || opts.TailOutput != ""
+}
+
+// envVarKeyRefs rewrites the Secret and ConfigMap references of env vars the same way they are
+// parsed, so they are validated as parsed
+func envVarKeyRefs(envs []string, keyRef func(string) string) []string {
+	refs := make([]string, len(envs))
+	for i, env := range envs {
+		refs[i] = keyRef(env)
+	}
+	return refs
+}
+
+// OutputConfigs returns the config for the output of the command and the config to tail the logs
+// with. When the logs are tailed as json, stdout is kept for the log lines, so the stream can be
+// piped to a json processor, and the other output is written to stderr. The config is not changed.
+func (opts *WorkloadOptions) OutputConfigs(c *cli.Config) (out, tail *cli.Config) {
+	if opts.TailOutput != printer.OutputFormatJson {
+		return c, c
+	}
+	outConfig := *c
+	outConfig.Stdout = c.Stderr
+	return &outConfig, c
+}
+
+// defaultsSourceImage checks if the source image of the project config applies to the workload. It
+// does when local source is published without a source image, or when the workload has no source
+// nor image at all.
+func (opts *WorkloadOptions) defaultsSourceImage(workload *cartov1alpha1.Workload) bool {
+	if opts.ProjectSourceImage == "" || opts.Image != "" {
+		return false
+	}
+	if workload.Spec.Source != nil && workload.Spec.Source.Image != "" {
+		return false
+	}
+	if opts.LocalPath != "" {
+		return true
+	}
+	return workload.Spec.Source == nil && workload.Spec.Image == ""
+}
+
 func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, workload *cartov1alpha1.Workload) {
 	for _, label := range opts.Labels {
 		parts := parsers.DeletableKeyValue(label)
@@ -246,6 +469,8 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, workloa
 
 	if opts.SourceImage != "" {
 		workload.Spec.MergeSourceImage(opts.SourceImage)
+	} else if opts.defaultsSourceImage(workload) {
+		workload.Spec.MergeSourceImage(opts.ProjectSourceImage)
 	}
 
 	if cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.SubPathFlagName)) {
@@ -256,6 +481,23 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, workloa
 		workload.Spec.MergeImage(opts.Image)
 	}
 
+	// variables from the env files are applied first so flags can override them
+	for _, ev := range opts.EnvFileVars {
+		if ev.Delete {
+			workload.Spec.RemoveEnv(ev.Name)
+		} else {
+			workload.Spec.MergeEnv(corev1.EnvVar{Name: ev.Name, Value: ev.Value})
+		}
+	}
+
+	for _, ev := range opts.BuildEnvFileVars {
+		if ev.Delete {
+			workload.Spec.RemoveBuildEnv(ev.Name)
+		} else {
+			workload.Spec.MergeBuildEnv(corev1.EnvVar{Name: ev.Name, Value: ev.Value})
+		}
+	}
+
 	for _, ev := range opts.Env {
 		env, delete := parsers.DeletableEnvVar(ev)
 		if delete {
@@ -274,6 +516,22 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, workloa
 		}
 	}
 
+	for _, ev := range opts.EnvFromSecret {
+		workload.Spec.MergeEnv(parsers.EnvVarFromSecret(ev))
+	}
+
+	for _, ev := range opts.EnvFromConfigMap {
+		workload.Spec.MergeEnv(parsers.EnvVarFromConfigMap(ev))
+	}
+
+	for _, ev := range opts.BuildEnvFromSecret {
+		workload.Spec.MergeBuildEnv(parsers.EnvVarFromSecret(ev))
+	}
+
+	for _, ev := range opts.BuildEnvFromConfigMap {
+		workload.Spec.MergeBuildEnv(parsers.EnvVarFromConfigMap(ev))
+	}
+
 	for _, ref := range opts.ServiceRefs {
 		parts := parsers.DeletableKeyValue(ref)
 		serviceRefKey := parts[0]
@@ -328,7 +586,8 @@ func (opts *WorkloadOptions) ApplyOptionsToWorkload(ctx context.Context, workloa
 		})
 	}
 
-	if cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.ServiceAccountFlagName)) {
+	// the service account is also defaulted by the project config
+	if cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.ServiceAccountFlagName)) || opts.ServiceAccountName != "" {
 		workload.Spec.MergeServiceAccountName(opts.ServiceAccountName)
 	}
 }
@@ -342,38 +601,265 @@ func (opts *WorkloadOptions) PublishLocalSource(ctx context.Context, c *cli.Conf
 	}
 
 	taggedImage := strings.Split(workload.Spec.Source.Image, "@sha")[0]
-	okToPush := opts.checkToPublishLocalSource(taggedImage, c, workload)
-	if !okToPush {
-		return okToPush, nil
+
+	contentDir, cleanup, err := opts.localSourceDir(c)
+	defer cleanup()
+	if err != nil {
+		return false, err
+	}
+	ignore, err := source.LoadIgnore(contentDir)
+	if err != nil {
+		return false, err
+	}
+	files, excludedPaths, err := source.ListSourceFiles(contentDir, ignore)
+	if err != nil {
+		return false, err
 	}
 
-	var contentDir string
-	if source.IsDir(opts.LocalPath) {
-		contentDir = opts.LocalPath
-	} else if source.IsZip(opts.LocalPath) {
-		zipContentsDir, err := ioutil.TempDir("", "")
-		defer os.RemoveAll(zipContentsDir)
-		if err != nil {
+	sourceImage, err := source.NewSourceImage(contentDir, excludedPaths)
+	if err != nil {
+		return false, err
+	}
+	defer sourceImage.Remove()
+	digest, err := sourceImage.Digest()
+	if err != nil {
+		return false, err
+	}
+
+	if opts.SourceTarball != "" || opts.SourceOCILayout != "" {
+		// the source is imported to the registry later on, without changing its digest
+		if err := opts.writeLocalSource(c, sourceImage, taggedImage); err != nil {
 			return false, err
 		}
-		if err = source.ExtractZip(zipContentsDir, opts.LocalPath); err != nil {
-			c.Errorf("Failed to extract file contents from %q. \n", opts.LocalPath)
+		workload.Spec.Source.Image = fmt.Sprintf("%s@%s", taggedImage, digest)
+		return true, nil
+	}
+
+	remoteOptions, err := opts.RegistryOptions().RemoteOptions()
+	if err != nil {
+		return false, err
+	}
+	ctx = source.StashGgcrRemoteOptions(ctx, append(source.RetrieveGgcrRemoteOptions(ctx), remoteOptions...)...)
+
+	var signer *source.Signer
+	if opts.SourceSigningKey != "" {
+		if signer, err = source.LoadSigner(opts.SourceSigningKey); err != nil {
 			return false, err
 		}
-		contentDir = zipContentsDir
-	} else {
-		return false, fmt.Errorf("unsupported file format %q", opts.LocalPath)
+	}
+
+	if isPublishedSource(ctx, workload.Spec.Source.Image, taggedImage, digest) {
+		c.Infof("Source in %q is unchanged, skipping publish\n", opts.LocalPath)
+		workload.Spec.Source.Image = fmt.Sprintf("%s@%s", taggedImage, digest)
+		// the source may have been published without being signed
+		return true, opts.signLocalSource(ctx, c, signer, workload.Spec.Source.Image)
+	}
+
+	okToPush := opts.checkToPublishLocalSource(taggedImage, c, workload)
+	if !okToPush {
+		return okToPush, nil
 	}
 
 	c.Infof("Publishing source in %q to %q...\n", opts.LocalPath, taggedImage)
-	digestedImage, err := source.ImgpkgPush(ctx, contentDir, taggedImage)
+	onProgress, clearProgress := printUploadProgress(c)
+	digestedImage, progress, err := sourceImage.Push(ctx, taggedImage, onProgress)
+	clearProgress()
 	if err != nil {
 		return okToPush, err
 	}
 	workload.Spec.Source.Image = digestedImage
 	c.Successf("Published source\n")
+	var size int64
+	for _, file := range files {
+		size += file.Size
+	}
+	c.Infof("%d files, %s (%s compressed, %s uploaded)\n", len(files), units.HumanSize(float64(size)), units.HumanSize(float64(progress.Total)), units.HumanSize(float64(progress.Complete)))
+
+	return okToPush, opts.signLocalSource(ctx, c, signer, digestedImage)
+}
+
+// signLocalSource signs the published source and attests its provenance, when there is a signer
+func (opts *WorkloadOptions) signLocalSource(ctx context.Context, c *cli.Config, signer *source.Signer, digestedImage string) error {
+	if signer == nil {
+		return nil
+	}
+	if err := signer.Sign(ctx, digestedImage, opts.SourceProvenance(ctx, c)); err != nil {
+		return err
+	}
+	c.Successf("Signed source and attested its provenance\n")
+	return nil
+}
+
+// SourceProvenance describes where the published local source comes from. The git details are
+// only known when the local path is a directory of a git repository, the uploader defaults to the
+// current user.
+func (opts *WorkloadOptions) SourceProvenance(ctx context.Context, c *cli.Config) source.Provenance {
+	provenance := source.Provenance{LocalPath: opts.LocalPath}
+	git := func(args ...string) (string, error) {
+		out, err := c.Exec(ctx, "git", append([]string{"-C", opts.LocalPath}, args...)...).Output()
+		return strings.TrimSpace(string(out)), err
+	}
+	if source.IsDir(opts.LocalPath) {
+		if commit, err := git("rev-parse", "HEAD"); err == nil {
+			provenance.GitCommit = commit
+			status, err := git("status", "--porcelain", "--", ".")
+			provenance.GitDirty = err != nil || status != ""
+		}
+		if email, err := git("config", "user.email"); err == nil {
+			provenance.Uploader = email
+		}
+	}
+	if provenance.Uploader == "" {
+		if u, err := user.Current(); err == nil {
+			provenance.Uploader = u.Username
+		}
+	}
+	return provenance
+}
+
+// writeLocalSource writes the source image to disk rather than publishing it, for it to be
+// imported to the registry of the source image by other means
+func (opts *WorkloadOptions) writeLocalSource(c *cli.Config, sourceImage *source.SourceImage, taggedImage string) error {
+	var err error
+	if opts.SourceTarball != "" {
+		c.Infof("Writing source in %q to tarball %q...\n", opts.LocalPath, opts.SourceTarball)
+		err = sourceImage.WriteTarball(opts.SourceTarball, taggedImage)
+	} else {
+		c.Infof("Writing source in %q to OCI layout %q...\n", opts.LocalPath, opts.SourceOCILayout)
+		err = sourceImage.WriteLayout(opts.SourceOCILayout, taggedImage)
+	}
+	if err != nil {
+		return err
+	}
+	c.Successf("Wrote source, import it to %q before the workload can be built\n", taggedImage)
+	return nil
+}
+
+// printUploadProgress renders the upload progress on stderr, rewriting a single line on a terminal
+// and logging a line periodically otherwise. The returned func clears the rendered line.
+func printUploadProgress(c *cli.Config) (source.ProgressFunc, func()) {
+	tty := false
+	if f, ok := c.Stderr.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		tty = true
+	}
+	interval := 5 * time.Second
+	if tty {
+		interval = 100 * time.Millisecond
+	}
+
+	var m sync.Mutex
+	next := time.Now().Add(interval)
+	rendered, cleared := false, false
+	onProgress := func(p source.Progress) {
+		m.Lock()
+		defer m.Unlock()
+		now := time.Now()
+		if cleared || now.Before(next) {
+			return
+		}
+		next = now.Add(interval)
+		line := fmt.Sprintf("Uploading %s/%s, %d/%d layers, %s/s", units.HumanSize(float64(p.Complete)), units.HumanSize(float64(p.Total)), p.LayersComplete, p.Layers, units.HumanSize(p.Throughput()))
+		if remaining := p.Remaining(); remaining > 0 {
+			line += fmt.Sprintf(", %s remaining", remaining.Round(time.Second))
+		}
+		if tty {
+			c.Einfof("\r\033[K%s", line)
+			rendered = true
+		} else {
+			c.Einfof("%s\n", line)
+		}
+	}
+	clear := func() {
+		m.Lock()
+		defer m.Unlock()
+		if rendered {
+			c.Eprintf("\r\033[K")
+		}
+		cleared = true
+	}
+	return onProgress, clear
+}
+
+// isPublishedSource checks if the local source digest is the one of the workload source image, or
+// of the tag in the registry. The tag is not found when the source was never published.
+func isPublishedSource(ctx context.Context, image, taggedImage, digest string) bool {
+	if strings.HasSuffix(image, "@"+digest) {
+		return true
+	}
+	remoteDigest, err := source.RemoteDigest(ctx, taggedImage)
+	return err == nil && remoteDigest == digest
+}
+
+// RegistryOptions are the options to access the registry the local source is published to. The
+// credentials default to the environment when neither credentials nor a registry config are set.
+func (opts *WorkloadOptions) RegistryOptions() source.RegistryOptions {
+	registryOptions := source.RegistryOptions{
+		CACertPaths: opts.RegistryCACerts,
+		VerifyCerts: opts.RegistryVerifyCerts,
+		Username:    opts.RegistryUsername,
+		Password:    opts.RegistryPassword,
+		ConfigFile:  opts.RegistryConfig,
+	}
+	if registryOptions.Username == "" && registryOptions.ConfigFile == "" {
+		registryOptions.Username = os.Getenv(RegistryUsernameEnv)
+	}
+	if registryOptions.Password == "" && registryOptions.ConfigFile == "" {
+		registryOptions.Password = os.Getenv(RegistryPasswordEnv)
+	}
+	return registryOptions
+}
+
+// localSourceDir resolves the local path to a directory, extracting archives to a temporary
+// directory that is removed by the returned cleanup func
+func (opts *WorkloadOptions) localSourceDir(c *cli.Config) (string, func(), error) {
+	cleanup := func() {}
+	if source.IsDir(opts.LocalPath) {
+		return opts.LocalPath, cleanup, nil
+	}
+	if !source.IsArchive(opts.LocalPath) {
+		return "", cleanup, fmt.Errorf("unsupported file format %q", opts.LocalPath)
+	}
+	archiveContentsDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		return "", cleanup, err
+	}
+	cleanup = func() { os.RemoveAll(archiveContentsDir) }
+	if err = source.ExtractArchive(archiveContentsDir, opts.LocalPath); err != nil {
+		c.Errorf("Failed to extract file contents from %q. \n", opts.LocalPath)
+		return "", cleanup, err
+	}
+	return archiveContentsDir, cleanup, nil
+}
 
-	return okToPush, nil
+// PrintLocalSourceFiles lists the files of the local path that would be published, skipping the
+// paths excluded by the ignore file
+func (opts *WorkloadOptions) PrintLocalSourceFiles(c *cli.Config) error {
+	if opts.LocalPath == "" {
+		return nil
+	}
+
+	contentDir, cleanup, err := opts.localSourceDir(c)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+	ignore, err := source.LoadIgnore(contentDir)
+	if err != nil {
+		return err
+	}
+	files, _, err := source.ListSourceFiles(contentDir, ignore)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	c.Infof("Source files in %q to publish:\n", opts.LocalPath)
+	for _, file := range files {
+		c.Printf("  %s (%s)\n", filepath.ToSlash(file.Path), units.HumanSize(float64(file.Size)))
+		total += file.Size
+	}
+	c.Infof("%d files, %s total\n", len(files), units.HumanSize(float64(total)))
+	return nil
 }
 
 func (opts *WorkloadOptions) checkToPublishLocalSource(taggedImage string, c *cli.Config, workload *cartov1alpha1.Workload) bool {
@@ -427,13 +913,19 @@ func (opts *WorkloadOptions) Update(ctx context.Context, c *cli.Config, currentW
 		}
 	}
 
-	if err := c.Update(ctx, workload); err != nil {
+	if updated, err := opts.updateWorkload(ctx, c, currentWorkload, workload); err != nil {
 		okToUpdate = false
+		if conflicts := applyConflicts(err); len(conflicts) != 0 {
+			opts.printApplyConflicts(c, workload, conflicts)
+			return okToUpdate, cli.SilenceError(err)
+		}
 		if apierrs.IsConflict(err) {
 			c.Printf("%s conflict updating workload, the object was modified by another user; please run the update command again\n", printer.Serrorf("Error:"))
 			return okToUpdate, cli.SilenceError(err)
 		}
 		return okToUpdate, err
+	} else if !updated {
+		return false, nil
 	}
 
 	c.Successf("Updated workload %q\n", workload.Name)
@@ -473,7 +965,11 @@ func (opts *WorkloadOptions) Create(ctx context.Context, c *cli.Config, workload
 		}
 	}
 
-	if err := c.Create(ctx, workload); err != nil {
+	if err := opts.writeWorkload(ctx, c, nil, workload); err != nil {
+		if conflicts := applyConflicts(err); len(conflicts) != 0 {
+			opts.printApplyConflicts(c, workload, conflicts)
+			return okToCreate, cli.SilenceError(err)
+		}
 		return okToCreate, err
 	}
 	c.Successf("Created workload %q\n", workload.Name)
@@ -481,32 +977,660 @@ func (opts *WorkloadOptions) Create(ctx context.Context, c *cli.Config, workload
 	return okToCreate, nil
 }
 
+// writeWorkload creates the workload when there is no current workload, otherwise the workload is
+// updated. With server-side apply, the workload is applied in both cases, unless fields are removed
+// that were not applied by the cli.
+func (opts *WorkloadOptions) writeWorkload(ctx context.Context, c *cli.Config, currentWorkload *cartov1alpha1.Workload, workload *cartov1alpha1.Workload) error {
+	if opts.ServerSide {
+		if currentWorkload != nil {
+			unowned, err := removesUnownedFields(currentWorkload, workload)
+			if err != nil {
+				return err
+			}
+			if unowned {
+				// server-side apply keeps the fields owned by other managers, removing them needs an update
+				c.Infof("WARNING: fields removed from workload %q are not managed by %s, updating the workload instead of applying it\n", workload.Name, WorkloadFieldManager)
+				return c.Update(ctx, workload)
+			}
+		}
+		return opts.applyWorkload(ctx, c, workload)
+	}
+	if currentWorkload == nil {
+		return c.Create(ctx, workload)
+	}
+	return c.Update(ctx, workload)
+}
+
+// updateWorkload updates the workload. With --retry-on-conflict, each time the update conflicts with a
+// concurrent change, the changes from the current workload to the workload are applied to a fresh copy
+// of the workload and the update is retried after a growing delay. The updated changes are only shown,
+// and confirmed unless --yes is set, when they differ from the original changes.
+// Returns false when the updated changes are not confirmed or no longer change the workload.
+func (opts *WorkloadOptions) updateWorkload(ctx context.Context, c *cli.Config, currentWorkload *cartov1alpha1.Workload, workload *cartov1alpha1.Workload) (bool, error) {
+	err := opts.writeWorkload(ctx, c, currentWorkload, workload)
+	if !opts.RetryOnConflict {
+		return true, err
+	}
+
+	backoff := retryOnConflictBackoff
+	for backoff.Steps > 0 {
+		if !apierrs.IsConflict(err) || len(applyConflicts(err)) != 0 {
+			break
+		}
+		c.Infof("Workload %q was modified by another user, retrying update\n", workload.Name)
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(backoff.Step()):
+		}
+
+		freshWorkload := &cartov1alpha1.Workload{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(workload), freshWorkload); err != nil {
+			return false, err
+		}
+		rebasedWorkload, changed, rebaseErr := rebaseWorkload(currentWorkload, workload, freshWorkload)
+		if rebaseErr != nil {
+			return false, rebaseErr
+		}
+		if changed {
+			difference, noChange, err := printer.ResourceDiff(freshWorkload, rebasedWorkload, c.Scheme)
+			if err != nil {
+				return false, err
+			}
+			if noChange {
+				c.Infof("Workload is unchanged, skipping update\n")
+				return false, nil
+			}
+			c.Printf("Update workload %q:\n", workload.Name)
+			c.Printf("%s\n", difference)
+			if !opts.Yes {
+				okToUpdate := false
+				err := survey.AskOne(&survey.Confirm{
+					Message: fmt.Sprintf("The workload %q was modified by another user, really update the workload?", workload.Name),
+				}, &okToUpdate, printer.WithSurveyStdio(c.Stdin, c.Stdout, c.Stderr))
+				if err != nil || !okToUpdate {
+					c.Infof("Skipping workload %q\n", workload.Name)
+					return false, nil
+				}
+			}
+		}
+
+		currentWorkload, workload = freshWorkload, rebasedWorkload
+		err = opts.writeWorkload(ctx, c, currentWorkload, workload)
+	}
+	return true, err
+}
+
+// rebaseWorkload applies the changes from the current workload to the workload on top of a fresh copy of
+// the workload. The changes already carry the flags, and the source published from a local path, so the
+// options are not applied again. The returned bool is true when the changes conflict with the
+// concurrent changes between the current and the fresh workload, so the effective changes differ.
+func rebaseWorkload(currentWorkload, workload, freshWorkload *cartov1alpha1.Workload) (*cartov1alpha1.Workload, bool, error) {
+	patch, err := createWorkloadPatch(currentWorkload, workload)
+	if err != nil {
+		return nil, false, err
+	}
+	fresh, err := json.Marshal(freshWorkload)
+	if err != nil {
+		return nil, false, err
+	}
+	rebased, err := strategicpatch.StrategicMergePatch(fresh, patch, &cartov1alpha1.Workload{})
+	if err != nil {
+		return nil, false, err
+	}
+	rebasedWorkload := &cartov1alpha1.Workload{}
+	if err := json.Unmarshal(rebased, rebasedWorkload); err != nil {
+		return nil, false, err
+	}
+
+	concurrentPatch, err := createWorkloadPatch(currentWorkload, freshWorkload)
+	if err != nil {
+		return nil, false, err
+	}
+	patchMap := map[string]interface{}{}
+	if err := json.Unmarshal(patch, &patchMap); err != nil {
+		return nil, false, err
+	}
+	concurrentPatchMap := map[string]interface{}{}
+	if err := json.Unmarshal(concurrentPatch, &concurrentPatchMap); err != nil {
+		return nil, false, err
+	}
+	schema, err := strategicpatch.NewPatchMetaFromStruct(&cartov1alpha1.Workload{})
+	if err != nil {
+		return nil, false, err
+	}
+	conflict, err := strategicpatch.MergingMapsHaveConflicts(patchMap, concurrentPatchMap, schema)
+	if err != nil {
+		return nil, false, err
+	}
+	return rebasedWorkload, conflict, nil
+}
+
+func createWorkloadPatch(original, modified *cartov1alpha1.Workload) ([]byte, error) {
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return nil, err
+	}
+	modifiedJSON, err := json.Marshal(modified)
+	if err != nil {
+		return nil, err
+	}
+	return strategicpatch.CreateTwoWayMergePatch(originalJSON, modifiedJSON, &cartov1alpha1.Workload{})
+}
+
+// applyWorkload submits the metadata and spec of the workload with a server-side apply patch. The
+// fields are owned by WorkloadFieldManager, fields with a different value owned by another manager
+// are reported as conflicts unless conflicts are forced.
+func (opts *WorkloadOptions) applyWorkload(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) error {
+	applyWorkload := appliedWorkload(workload)
+	applyWorkload.SetGroupVersionKind(applyWorkload.GetGroupVersionKind())
+
+	patchOpts := []client.PatchOption{client.FieldOwner(WorkloadFieldManager)}
+	if opts.ForceConflicts {
+		patchOpts = append(patchOpts, client.ForceOwnership)
+	}
+	return c.Patch(ctx, applyWorkload, client.Apply, patchOpts...)
+}
+
+// appliedWorkload is the part of the workload submitted with server-side apply
+func appliedWorkload(workload *cartov1alpha1.Workload) *cartov1alpha1.Workload {
+	return &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   workload.Namespace,
+			Name:        workload.Name,
+			Labels:      workload.Labels,
+			Annotations: workload.Annotations,
+		},
+		Spec: workload.Spec,
+	}
+}
+
+// removesUnownedFields checks if fields of the current workload are removed from the workload while
+// they are not owned by the server-side applies of WorkloadFieldManager. Server-side apply only
+// removes the fields the manager owns, any other field would silently be kept.
+func removesUnownedFields(currentWorkload, workload *cartov1alpha1.Workload) (bool, error) {
+	var owned map[string]interface{}
+	for _, entry := range currentWorkload.ManagedFields {
+		if entry.Manager != WorkloadFieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &owned); err != nil {
+			return false, err
+		}
+	}
+	current, err := runtime.DefaultUnstructuredConverter.ToUnstructured(appliedWorkload(currentWorkload))
+	if err != nil {
+		return false, err
+	}
+	next, err := runtime.DefaultUnstructuredConverter.ToUnstructured(appliedWorkload(workload))
+	if err != nil {
+		return false, err
+	}
+	return removesUnownedValues(current, next, owned), nil
+}
+
+// removesUnownedValues walks the current value along the owned fields, in the managed fields format.
+// Owned fields without children are owned as a whole, the children of a removed field must be owned
+// too. List items are identified by name, like the lists of a workload, otherwise by value.
+func removesUnownedValues(current, next interface{}, owned map[string]interface{}) bool {
+	if owned != nil && len(owned) == 0 {
+		return false
+	}
+	switch current := current.(type) {
+	case map[string]interface{}:
+		next, _ := next.(map[string]interface{})
+		for key, value := range current {
+			ownedValue, _ := owned["f:"+key].(map[string]interface{})
+			nextValue, ok := next[key]
+			if !ok && ownedValue == nil {
+				return true
+			}
+			if removesUnownedValues(value, nextValue, ownedValue) {
+				return true
+			}
+		}
+	case []interface{}:
+		next, _ := next.([]interface{})
+		nextItems := map[string]interface{}{}
+		for _, item := range next {
+			nextItems[listItemKey(item)] = item
+		}
+		for _, item := range current {
+			key := listItemKey(item)
+			ownedItem, _ := owned[key].(map[string]interface{})
+			nextItem, ok := nextItems[key]
+			if !ok && ownedItem == nil {
+				return true
+			}
+			if removesUnownedValues(item, nextItem, ownedItem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listItemKey is the key of a list item in the managed fields
+func listItemKey(item interface{}) string {
+	if m, ok := item.(map[string]interface{}); ok {
+		if name, ok := m["name"]; ok {
+			key, _ := json.Marshal(map[string]interface{}{"name": name})
+			return "k:" + string(key)
+		}
+	}
+	value, _ := json.Marshal(item)
+	return "v:" + string(value)
+}
+
+// applyConflicts returns the fields of a server-side apply that are managed by another field
+// manager, if any.
+func applyConflicts(err error) []metav1.StatusCause {
+	var status apierrs.APIStatus
+	if !errors.As(err, &status) {
+		return nil
+	}
+	if status.Status().Reason != metav1.StatusReasonConflict || status.Status().Details == nil {
+		return nil
+	}
+	conflicts := []metav1.StatusCause{}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, cause)
+		}
+	}
+	return conflicts
+}
+
+func (opts *WorkloadOptions) printApplyConflicts(c *cli.Config, workload *cartov1alpha1.Workload, conflicts []metav1.StatusCause) {
+	c.Printf("%s conflict applying workload %q, the following fields are managed by another field manager:\n", printer.Serrorf("Error:"), workload.Name)
+	if err := printer.WorkloadApplyConflictsPrinter(c.Stdout, workload, conflicts); err != nil {
+		c.Eprintf("%s %s\n", printer.Serrorf("Error:"), err)
+	}
+	c.Infof("To take ownership of these fields, run the command again with %s\n", flags.ForceConflictsFlagName)
+}
+
 func (opts *WorkloadOptions) LoadInputWorkload(input io.Reader, workload *cartov1alpha1.Workload) error {
+	workloads, err := loadInputWorkloads(opts.FilePath, input, opts.TemplateVars())
+	if err != nil {
+		return err
+	}
+	if err := opts.applyOverlays(workloads); err != nil {
+		return err
+	}
+	if len(workloads) > 1 {
+		return fmt.Errorf("unable to load file %q: files containing multiple workload descriptions are not supported", opts.FilePath)
+	}
+	workloads[0].DeepCopyInto(workload)
+	return nil
+}
+
+// LoadInputWorkloads reads every workload described by the file path. The file may contain multiple
+// yaml documents, or be a directory, in which case each .yaml, .yml and .json file within it is read in
+// lexical order. When template variables are set, each file is rendered as a template first. The
+// overlays are then applied to the workloads.
+func (opts *WorkloadOptions) LoadInputWorkloads(input io.Reader) ([]*cartov1alpha1.Workload, error) {
+	workloads, err := loadInputWorkloads(opts.FilePath, input, opts.TemplateVars())
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.applyOverlays(workloads); err != nil {
+		return nil, err
+	}
+	return workloads, nil
+}
+
+func loadInputWorkloads(filePath string, input io.Reader, vars map[string]string) ([]*cartov1alpha1.Workload, error) {
+	if filePath != "-" && source.IsDir(filePath) {
+		return loadWorkloadsFromDir(filePath, vars)
+	}
+
 	var in io.Reader
 
-	f, err := os.Open(opts.FilePath)
+	f, err := os.Open(filePath)
 	in = f
-	if f == nil && opts.FilePath == "-" {
+	if f == nil && filePath == "-" {
 		in = input
 	} else if err != nil {
-		return fmt.Errorf("unable to open file %q: %w", opts.FilePath, err)
+		return nil, fmt.Errorf("unable to open file %q: %w", filePath, err)
 	}
 	defer f.Close()
 
-	if err := workload.Load(in); err != nil {
-		return fmt.Errorf("unable to load file %q: %w", opts.FilePath, err)
+	if vars != nil {
+		content, err := io.ReadAll(in)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read file %q: %w", filePath, err)
+		}
+		rendered, err := template.Render(filePath, content, vars)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render file %q: %w", filePath, err)
+		}
+		in = bytes.NewReader(rendered)
+	}
+
+	workloads, err := cartov1alpha1.LoadWorkloads(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load file %q: %w", filePath, err)
+	}
+	return workloads, nil
+}
+
+func loadWorkloadsFromDir(dir string, vars map[string]string) ([]*cartov1alpha1.Workload, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read directory %q: %w", dir, err)
 	}
+
+	workloads := []*cartov1alpha1.Workload{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		switch filepath.Ext(file.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		fileWorkloads, err := loadInputWorkloads(filepath.Join(dir, file.Name()), nil, vars)
+		if err != nil {
+			return nil, err
+		}
+		workloads = append(workloads, fileWorkloads...)
+	}
+	if len(workloads) == 0 {
+		return nil, fmt.Errorf("unable to load directory %q: no workload files found", dir)
+	}
+	return workloads, nil
+}
+
+// mergeWorkload layers the file workload and the flag values on top of the workload that currently
+// exists on the cluster. The current workload is nil when there is no workload with the name yet.
+func (opts *WorkloadOptions) mergeWorkload(ctx context.Context, c *cli.Config, namespace, name string, fileWorkload *cartov1alpha1.Workload) (*cartov1alpha1.Workload, *cartov1alpha1.Workload, error) {
+	workload := &cartov1alpha1.Workload{}
+	var currentWorkload *cartov1alpha1.Workload
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, workload)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return nil, nil, err
+	}
+	if err == nil {
+		currentWorkload = workload.DeepCopy()
+	}
+
+	workload.Name = name
+	workload.Namespace = namespace
+	if opts.FilePath != "" {
+		var serviceAccountCopy string
+		// avoid passing a nil pointer to MergeServiceAccountName func
+		if fileWorkload.Spec.ServiceAccountName != nil {
+			serviceAccountCopy = *fileWorkload.Spec.ServiceAccountName
+		}
+
+		workload.Spec.MergeServiceAccountName(serviceAccountCopy)
+	}
+
+	workload.Merge(fileWorkload)
+
+	opts.ApplyOptionsToWorkload(ctx, workload)
+
+	return currentWorkload, workload, nil
+}
+
+type workloadChange struct {
+	current  *cartov1alpha1.Workload
+	workload *cartov1alpha1.Workload
+}
+
+// loadWorkloadChanges resolves the namespace of each file workload and computes the resulting
+// workload, with flags layered on top, along with the workload currently on the cluster.
+func (opts *WorkloadOptions) loadWorkloadChanges(ctx context.Context, c *cli.Config, fileWorkloads []*cartov1alpha1.Workload, createOnly bool) ([]workloadChange, error) {
+	cmd := cli.CommandFromContext(ctx)
+
+	changes := []workloadChange{}
+	seen := map[client.ObjectKey]bool{}
+	for _, fileWorkload := range fileWorkloads {
+		namespace := opts.Namespace
+		if fileWorkload.Namespace != "" && !cmd.Flags().Changed(cli.StripDash(flags.NamespaceFlagName)) {
+			namespace = fileWorkload.Namespace
+		}
+		key := client.ObjectKey{Namespace: namespace, Name: fileWorkload.Name}
+		if seen[key] {
+			return nil, fmt.Errorf("workload %q is described more than once in %q", key, opts.FilePath)
+		}
+		seen[key] = true
+
+		var change workloadChange
+		if createOnly {
+			change.workload = fileWorkload.DeepCopy()
+			change.workload.Namespace = namespace
+			existingWorkload := &cartov1alpha1.Workload{}
+			if err := c.Get(ctx, key, existingWorkload); err == nil {
+				c.Printf("%s workload %q already exists\n", printer.Serrorf("Error:"), key)
+				return nil, cli.SilenceError(errors.New(""))
+			} else if !apierrs.IsNotFound(err) {
+				return nil, err
+			}
+			opts.ApplyOptionsToWorkload(ctx, change.workload)
+		} else {
+			var err error
+			if change.current, change.workload, err = opts.mergeWorkload(ctx, c, namespace, fileWorkload.Name, fileWorkload); err != nil {
+				return nil, err
+			}
+		}
+
+		// validate complex flag interactions with existing state
+		if err := change.workload.Validate().ToAggregate(); err != nil {
+			// show command usage before error
+			cmd.SilenceUsage = false
+			return nil, fmt.Errorf("invalid workload %q: %w", key, err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// ApplyWorkloads creates or updates, in order, each workload loaded from a file containing more than
+// one workload. Flags are layered on top of every workload. The changes for all workloads are shown
+// and confirmed together, and the outcome for each workload is reported once all have been applied.
+// When createOnly is set, none of the workloads may exist yet.
+func (opts *WorkloadOptions) ApplyWorkloads(ctx context.Context, c *cli.Config, fileWorkloads []*cartov1alpha1.Workload, createOnly bool) error {
+	cmd := cli.CommandFromContext(ctx)
+
+	// options that only make sense for a single workload
+	errs := validation.FieldErrors{}
+	if opts.Name != "" {
+		errs = errs.Also(validation.ErrDisallowedFields(cli.NameArgumentName, "not supported when the file contains multiple workloads"))
+	}
+	if opts.LocalPath != "" {
+		errs = errs.Also(validation.ErrDisallowedFields(flags.LocalPathFlagName, "not supported when the file contains multiple workloads"))
+	}
+	if opts.anyTail() {
+		errs = errs.Also(validation.ErrDisallowedFields(flags.TailFlagName, "not supported when the file contains multiple workloads"))
+	}
+	if err := errs.ToAggregate(); err != nil {
+		// show command usage before error
+		cmd.SilenceUsage = false
+		return err
+	}
+
+	changes, err := opts.loadWorkloadChanges(ctx, c, fileWorkloads, createOnly)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		for _, change := range changes {
+			cli.DryRunResource(ctx, change.workload, change.workload.GetGroupVersionKind())
+		}
+		return nil
+	}
+
+	pending := []workloadChange{}
+	for _, change := range changes {
+		if msgs := change.workload.DeprecationWarnings(); len(msgs) != 0 {
+			for _, msg := range msgs {
+				c.Infof("WARNING: %s\n", msg)
+			}
+		}
+
+		difference, noChange, err := printer.ResourceDiff(change.current, change.workload, c.Scheme)
+		if err != nil {
+			return err
+		}
+		if change.current == nil {
+			c.Printf("Create workload %q:\n", change.workload.Name)
+		} else if noChange {
+			c.Infof("Workload %q is unchanged, skipping update\n", change.workload.Name)
+			continue
+		} else {
+			c.Printf("Update workload %q:\n", change.workload.Name)
+		}
+		c.Printf("%s\n", difference)
+		pending = append(pending, change)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if !opts.Yes {
+		if opts.FilePath == "-" {
+			c.Errorf("Skipping workloads, cannot confirm intent. Run command with %s flag to confirm intent when providing input from stdin\n", flags.YesFlagName)
+			return nil
+		}
+		okToApply := false
+		err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Really apply changes to %d workloads?", len(pending)),
+		}, &okToApply, printer.WithSurveyStdio(c.Stdin, c.Stdout, c.Stderr))
+		if err != nil || !okToApply {
+			c.Infof("Skipping workloads\n")
+			return nil
+		}
+	}
+
+	results := make([]error, len(pending))
+	skipped := make([]bool, len(pending))
+	for i, change := range pending {
+		if change.current == nil {
+			results[i] = opts.writeWorkload(ctx, c, nil, change.workload)
+			continue
+		}
+		updated, err := opts.updateWorkload(ctx, c, change.current, change.workload)
+		results[i], skipped[i] = err, err == nil && !updated
+	}
+
+	failed := 0
+	for i, change := range pending {
+		err := results[i]
+		switch {
+		case skipped[i]:
+			c.Infof("Skipping workload %q\n", change.workload.Name)
+		case err == nil && change.current == nil:
+			c.Successf("Created workload %q\n", change.workload.Name)
+		case err == nil:
+			c.Successf("Updated workload %q\n", change.workload.Name)
+		case len(applyConflicts(err)) != 0:
+			failed++
+			opts.printApplyConflicts(c, change.workload, applyConflicts(err))
+		case apierrs.IsConflict(err):
+			failed++
+			c.Printf("%s conflict updating workload %q, the object was modified by another user; please run the command again\n", printer.Serrorf("Error:"), change.workload.Name)
+		case change.current == nil:
+			failed++
+			c.Printf("%s failed to create workload %q: %s\n", printer.Serrorf("Error:"), change.workload.Name, err)
+		default:
+			failed++
+			c.Printf("%s failed to update workload %q: %s\n", printer.Serrorf("Error:"), change.workload.Name, err)
+		}
+	}
+	if failed != 0 {
+		return cli.SilenceError(fmt.Errorf("failed to apply %d of %d workloads", failed, len(pending)))
+	}
+
+	if opts.Wait {
+		for _, change := range pending {
+			workload := change.workload
+			c.Infof("Waiting for workload %q to become ready...\n", workload.Name)
+			workers := []wait.Worker{
+				func(ctx context.Context) error {
+					clientWithWatch, err := watch.GetWatcher(ctx, c)
+					if err != nil {
+						panic(err)
+					}
+					return wait.UntilCondition(ctx, clientWithWatch, types.NamespacedName{Name: workload.Name, Namespace: workload.Namespace}, &cartov1alpha1.WorkloadList{}, cartov1alpha1.WorkloadReadyConditionFunc)
+				},
+			}
+			if err := wait.Race(ctx, opts.WaitTimeout, workers); err != nil {
+				if err == context.DeadlineExceeded {
+					c.Printf("%s timeout after %s waiting for %q to become ready\n", printer.Serrorf("Error:"), opts.WaitTimeout, workload.Name)
+					c.Infof("To view status run: tanzu apps workload get %s %s %s\n", workload.Name, flags.NamespaceFlagName, workload.Namespace)
+					return cli.SilenceError(err)
+				}
+				c.Eprintf("%s %s\n", printer.Serrorf("Error:"), err)
+				return cli.SilenceError(err)
+			}
+			c.Infof("Workload %q is ready\n", workload.Name)
+		}
+	}
+
 	return nil
 }
 
 func (opts *WorkloadOptions) DefineFlags(ctx context.Context, c *cli.Config, cmd *cobra.Command) {
+	opts.defineWorkloadFlags(ctx, c, cmd)
+	cmd.Flags().StringVar(&opts.LocalPath, cli.StripDash(flags.LocalPathFlagName), "", "`path` to a directory, .zip, .jar, .tar, .tar.gz or .tgz file containing workload source code")
+	cmd.MarkFlagDirname(cli.StripDash(flags.LocalPathFlagName))
+	cmd.Flags().StringVar(&opts.SourceTarball, cli.StripDash(flags.SourceTarballFlagName), "", "`path` of a tarball to write the local source to rather than publishing it, the source image is the reference to import it to")
+	cmd.MarkFlagFilename(cli.StripDash(flags.SourceTarballFlagName), ".tar")
+	cmd.Flags().StringVar(&opts.SourceOCILayout, cli.StripDash(flags.SourceOCILayoutFlagName), "", "`path` of an OCI image layout directory to add the local source to rather than publishing it, the source image is the reference to import it to")
+	cmd.MarkFlagDirname(cli.StripDash(flags.SourceOCILayoutFlagName))
+	cmd.Flags().StringVar(&opts.SourceSigningKey, cli.StripDash(flags.SourceSigningKeyFlagName), "", "`path` to an unencrypted PEM private key, PKCS#8 or EC, to sign the published local source with, an attestation of its provenance is attached along with the signature")
+	cmd.MarkFlagFilename(cli.StripDash(flags.SourceSigningKeyFlagName), ".key", ".pem")
+	cmd.Flags().StringArrayVar(&opts.RegistryCACerts, cli.StripDash(flags.RegistryCACertFlagName), []string{}, "`path` to a PEM file of certificate authorities trusted when publishing local source, may be set multiple times")
+	cmd.MarkFlagFilename(cli.StripDash(flags.RegistryCACertFlagName), ".crt", ".pem")
+	cmd.Flags().BoolVar(&opts.RegistryVerifyCerts, cli.StripDash(flags.RegistryVerifyCertsFlagName), true, "verify the certificate of the registry when publishing local source")
+	cmd.Flags().StringVar(&opts.RegistryUsername, cli.StripDash(flags.RegistryUsernameFlagName), "", "`username` for the registry when publishing local source, defaults to $"+RegistryUsernameEnv)
+	cmd.Flags().StringVar(&opts.RegistryPassword, cli.StripDash(flags.RegistryPasswordFlagName), "", "`password` for the registry when publishing local source, defaults to $"+RegistryPasswordEnv)
+	cmd.Flags().StringVar(&opts.RegistryConfig, cli.StripDash(flags.RegistryConfigFlagName), "", "`path` to a docker config file with the credentials for the registry when publishing local source")
+	cmd.MarkFlagFilename(cli.StripDash(flags.RegistryConfigFlagName), ".json")
+	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(flags.WaitFlagName), false, "waits for workload to become ready")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(flags.WaitTimeoutFlagName), 10*time.Minute, "timeout for workload to become ready when waiting")
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.WaitTimeoutFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
+	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(flags.TailFlagName), false, "show logs while waiting for workload to become ready")
+	cmd.Flags().BoolVar(&opts.TailTimestamps, cli.StripDash(flags.TailTimestampFlagName), false, "show logs and add timestamp to each log line while waiting for workload to become ready")
+	cmd.Flags().StringVar(&opts.TailOutput, cli.StripDash(flags.TailOutputFlagName), "", "show logs formatted while waiting for workload to become ready, other output is written to stderr when logs are shown as json. Supported formats: \"json\"")
+	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(flags.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
+	cmd.Flags().BoolVarP(&opts.Yes, cli.StripDash(flags.YesFlagName), "y", false, "accept all prompts")
+	cmd.Flags().BoolVar(&opts.ServerSide, cli.StripDash(flags.ServerSideFlagName), false, "use server-side apply, fields managed by other tools or users are not overwritten and are reported as conflicts")
+	cmd.Flags().BoolVar(&opts.ForceConflicts, cli.StripDash(flags.ForceConflictsFlagName), false, "take ownership of the fields in conflict with other field managers when using "+flags.ServerSideFlagName)
+}
+
+// defineRetryOnConflictFlag defines the flag to retry conflicting updates, for the commands that update workloads
+func (opts *WorkloadOptions) defineRetryOnConflictFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&opts.RetryOnConflict, cli.StripDash(flags.RetryOnConflictFlagName), false, "retry updates that conflict with a concurrent change to the workload, reapplying the changes to the latest version of the workload")
+}
+
+// defineWorkloadFlags defines the flags that describe the desired state of a workload, without the
+// flags controlling how the workload is written to the cluster.
+func (opts *WorkloadOptions) defineWorkloadFlags(ctx context.Context, c *cli.Config, cmd *cobra.Command) {
 	cli.NamespaceFlag(ctx, cmd, c, &opts.Namespace)
-	cmd.Flags().StringVarP(&opts.FilePath, cli.StripDash(flags.FilePathFlagName), "f", "", "`file path` containing the description of a single workload, other flags are layered on top of this resource. Use value \"-\" to read from stdin")
+	// the project config is applied before the namespace is defaulted from the kube config
+	prior := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.ApplyProjectConfig(ctx, c, cmd); err != nil {
+			return err
+		}
+		if prior != nil {
+			return prior(cmd, args)
+		}
+		return nil
+	}
+	cmd.Flags().BoolVar(&opts.NoConfig, cli.StripDash(flags.NoConfigFlagName), false, "ignore the "+ProjectConfigFileName+" project config file")
+	cmd.Flags().StringVarP(&opts.FilePath, cli.StripDash(flags.FilePathFlagName), "f", "", "`file path` containing the description of one or more workloads, or a directory of such files, other flags are layered on top of each resource. Use value \"-\" to read from stdin")
+	cmd.Flags().StringArrayVar(&opts.Vars, cli.StripDash(flags.VarFlagName), []string{}, "variable resolving the ${NAME} and {{ .NAME }} placeholders of the workload file, represented as a `\"key=value\" pair` (flag can be used multiple times)")
+	cmd.Flags().StringVar(&opts.VarFile, cli.StripDash(flags.VarFileFlagName), "", "`file path` of a dotenv file with variables resolving the placeholders of the workload file, "+flags.VarFlagName+" flags take precedence")
+	cmd.Flags().StringArrayVar(&opts.Overlays, cli.StripDash(flags.OverlayFlagName), []string{}, "`file path` of a strategic merge patch or JSON patch applied to the workloads of the workload file (flag can be used multiple times, overlays are applied in order)")
 	cmd.Flags().StringVar(&opts.App, cli.StripDash(flags.AppFlagName), "", "application `name` the workload is a part of")
 	cmd.Flags().StringVar(&opts.Type, cli.StripDash(flags.TypeFlagName), "", "distinguish workload `type`")
-	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.TypeFlagName), func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"web"}, cobra.ShellCompDirectiveNoFileComp
-	})
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.TypeFlagName), completion.SuggestWorkloadTypes(ctx, c))
 	cmd.Flags().StringSliceVar(&opts.Labels, cli.StripDash(flags.LabelFlagName), []string{}, "label is represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
 	cmd.Flags().StringSliceVar(&opts.Annotations, cli.StripDash(flags.AnnotationFlagName), []string{}, "annotation is represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
 	cmd.Flags().StringArrayVar(&opts.Params, cli.StripDash(flags.ParamFlagName), []string{}, "additional parameters represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
@@ -519,23 +1643,20 @@ func (opts *WorkloadOptions) DefineFlags(ctx context.Context, c *cli.Config, cmd
 	cmd.Flags().StringVar(&opts.GitTag, cli.StripDash(flags.GitTagFlagName), "", "`tag` within the git repo to checkout")
 	cmd.Flags().StringVarP(&opts.SourceImage, cli.StripDash(flags.SourceImageFlagName), "s", "", "destination `image` repository where source code is staged before being built")
 	cmd.Flags().StringVar(&opts.SubPath, cli.StripDash(flags.SubPathFlagName), "", "relative `path` inside the repo or image to treat as application root (to unset, pass empty string \"\")")
-	cmd.Flags().StringVar(&opts.LocalPath, cli.StripDash(flags.LocalPathFlagName), "", "`path` to a directory, .zip, or .jar file containing workload source code")
-	cmd.MarkFlagDirname(cli.StripDash(flags.LocalPathFlagName))
 	cmd.Flags().StringVar(&opts.Image, cli.StripDash(flags.ImageFlagName), "", "pre-built `image`, skips the source resolution and build phases of the supply chain")
 	cmd.Flags().StringArrayVar(&opts.Env, cli.StripDash(flags.EnvFlagName), []string{}, "environment variables represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
 	cmd.Flags().StringArrayVar(&opts.BuildEnv, cli.StripDash(flags.BuildEnvFlagName), []string{}, "build environment variables represented as a `\"key=value\" pair` (\"key-\" to remove, flag can be used multiple times)")
+	cmd.Flags().StringVar(&opts.EnvFile, cli.StripDash(flags.EnvFileFlagName), "", "`file path` of a dotenv file with environment variables (\"key-\" to remove), "+flags.EnvFlagName+" flags take precedence")
+	cmd.Flags().StringVar(&opts.BuildEnvFile, cli.StripDash(flags.BuildEnvFileFlagName), "", "`file path` of a dotenv file with build environment variables (\"key-\" to remove), "+flags.BuildEnvFlagName+" flags take precedence")
+	cmd.Flags().StringArrayVar(&opts.EnvFromSecret, cli.StripDash(flags.EnvFromSecretFlagName), []string{}, "environment variables set from a Secret represented as a `\"key=secret:secret-key\" pair` (flag can be used multiple times)")
+	cmd.Flags().StringArrayVar(&opts.EnvFromConfigMap, cli.StripDash(flags.EnvFromConfigMapFlagName), []string{}, "environment variables set from a ConfigMap represented as a `\"key=configmap:configmap-key\" pair` (flag can be used multiple times)")
+	cmd.Flags().StringArrayVar(&opts.BuildEnvFromSecret, cli.StripDash(flags.BuildEnvFromSecretFlagName), []string{}, "build environment variables set from a Secret represented as a `\"key=secret:secret-key\" pair` (flag can be used multiple times)")
+	cmd.Flags().StringArrayVar(&opts.BuildEnvFromConfigMap, cli.StripDash(flags.BuildEnvFromConfigMapFlagName), []string{}, "build environment variables set from a ConfigMap represented as a `\"key=configmap:configmap-key\" pair` (flag can be used multiple times)")
 	cmd.Flags().StringArrayVar(&opts.ServiceRefs, cli.StripDash(flags.ServiceRefFlagName), []string{}, "`object reference` for a service to bind to the workload \"service-ref-name=apiVersion:kind:service-binding-name\" (\"service-ref-name-\" to remove, flag can be used multiple times)")
 	cmd.Flags().StringVar(&opts.ServiceAccountName, cli.StripDash(flags.ServiceAccountFlagName), "", "name of service account permitted to create resources submitted by the supply chain (to unset, pass empty string \"\")")
 	cmd.Flags().StringVar(&opts.LimitCPU, cli.StripDash(flags.LimitCPUFlagName), "", "the maximum amount of cpu allowed, in CPU `cores` (500m = .5 cores)")
 	cmd.Flags().StringVar(&opts.LimitMemory, cli.StripDash(flags.LimitMemoryFlagName), "", "the maximum amount of memory allowed, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
 	cmd.Flags().StringVar(&opts.RequestCPU, cli.StripDash(flags.RequestCPUFlagName), "", "the minimum amount of cpu required, in CPU `cores` (500m = .5 cores)")
 	cmd.Flags().StringVar(&opts.RequestMemory, cli.StripDash(flags.RequestMemoryFlagName), "", "the minimum amount of memory required, in `bytes` (500Mi = 500MiB = 500 * 1024 * 1024)")
-	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(flags.WaitFlagName), false, "waits for workload to become ready")
-	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(flags.WaitTimeoutFlagName), 10*time.Minute, "timeout for workload to become ready when waiting")
-	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.WaitTimeoutFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
-	cmd.Flags().BoolVar(&opts.Tail, cli.StripDash(flags.TailFlagName), false, "show logs while waiting for workload to become ready")
-	cmd.Flags().BoolVar(&opts.TailTimestamps, cli.StripDash(flags.TailTimestampFlagName), false, "show logs and add timestamp to each log line while waiting for workload to become ready")
 	cmd.MarkFlagFilename(cli.StripDash(flags.FilePathFlagName), ".yaml", ".yml")
-	cmd.Flags().BoolVar(&opts.DryRun, cli.StripDash(flags.DryRunFlagName), false, "print kubernetes resources to stdout rather than apply them to the cluster, messages normally on stdout will be sent to stderr")
-	cmd.Flags().BoolVarP(&opts.Yes, cli.StripDash(flags.YesFlagName), "y", false, "accept all prompts")
 }
@@ -23,10 +23,8 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
@@ -54,6 +52,9 @@ func (opts *WorkloadApplyOptions) Validate(ctx context.Context) validation.Field
 }
 
 func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error {
+	// the output of the command goes to stderr while the logs are tailed as json to stdout
+	out, tailConfig := opts.OutputConfigs(c)
+	c = out
 	var createError error
 	var updateError error
 	okToCreate := false
@@ -61,9 +62,14 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 
 	fileWorkload := &cartov1alpha1.Workload{}
 	if opts.FilePath != "" {
-		if err := opts.WorkloadOptions.LoadInputWorkload(c.Stdin, fileWorkload); err != nil {
+		fileWorkloads, err := opts.WorkloadOptions.LoadInputWorkloads(c.Stdin)
+		if err != nil {
 			return err
 		}
+		if len(fileWorkloads) > 1 {
+			return opts.WorkloadOptions.ApplyWorkloads(ctx, c, fileWorkloads, false)
+		}
+		fileWorkload = fileWorkloads[0]
 
 		if opts.Name == "" {
 			opts.Name = fileWorkload.Name
@@ -85,31 +91,10 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 		return err
 	}
 
-	workload := &cartov1alpha1.Workload{}
-	var currentWorkload *cartov1alpha1.Workload
-	err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}, workload)
-	if err != nil && !apierrs.IsNotFound(err) {
+	currentWorkload, workload, err := opts.mergeWorkload(ctx, c, opts.Namespace, opts.Name, fileWorkload)
+	if err != nil {
 		return err
 	}
-	if err == nil {
-		currentWorkload = workload.DeepCopy()
-	}
-
-	workload.Name = opts.Name
-	workload.Namespace = opts.Namespace
-	if opts.FilePath != "" {
-		var serviceAccountCopy string
-		// avoid passing a nil pointer to MergeServiceAccountName func
-		if fileWorkload.Spec.ServiceAccountName != nil {
-			serviceAccountCopy = *fileWorkload.Spec.ServiceAccountName
-		}
-
-		workload.Spec.MergeServiceAccountName(serviceAccountCopy)
-	}
-
-	workload.Merge(fileWorkload)
-
-	opts.ApplyOptionsToWorkload(ctx, workload)
 
 	// validate complex flag interactions with existing state
 	errs = workload.Validate()
@@ -126,6 +111,9 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 	}
 
 	if opts.DryRun {
+		if err := opts.PrintLocalSourceFiles(c); err != nil {
+			return err
+		}
 		cli.DryRunResource(ctx, workload, workload.GetGroupVersionKind())
 		return nil
 	}
@@ -150,7 +138,7 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 		}
 	}
 
-	anyTail := opts.Tail || opts.TailTimestamps
+	anyTail := opts.anyTail()
 	if (opts.Yes || okToCreate || okToUpdate) && (opts.Wait || anyTail) {
 		c.Infof("Waiting for workload %q to become ready...\n", opts.Name)
 
@@ -171,7 +159,7 @@ func (opts *WorkloadApplyOptions) Exec(ctx context.Context, c *cli.Config) error
 					panic(err)
 				}
 				containers := []string{}
-				return logs.Tail(ctx, c, opts.Namespace, selector, containers, time.Second, opts.TailTimestamps)
+				return logs.Tail(ctx, tailConfig, opts.Namespace, selector, containers, time.Second, opts.TailTimestamps, opts.TailOutput, logs.TailOptions{})
 			})
 		}
 
@@ -210,6 +198,7 @@ Workload configuration options include:
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s workload apply %s workload.yaml", c.Name, flags.FilePathFlagName),
+			fmt.Sprintf("%s workload apply %s workloads/", c.Name, flags.FilePathFlagName),
 		}, "\n"),
 		PreRunE:           cli.ValidateE(ctx, opts),
 		RunE:              cli.ExecE(ctx, c, opts),
@@ -222,6 +211,7 @@ Workload configuration options include:
 
 	// Define common flags
 	opts.DefineFlags(ctx, c, cmd)
+	opts.defineRetryOnConflictFlag(cmd)
 
 	return cmd
 }
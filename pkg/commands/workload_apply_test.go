@@ -18,6 +18,7 @@ package commands_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -420,7 +422,7 @@ Workload "my-workload" is ready
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, false, "", logs.TailOptions{}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -526,6 +528,134 @@ Workload "my-workload" is ready
 			},
 			ShouldError: true,
 		},
+		{
+			Name: "create - project config defaults",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/project.yaml"
+				return ctx, nil
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    app.kubernetes.io/part-of: spring-petclinic
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: my-namespace
+spec:
+  serviceAccountName: my-service-account
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - flags take precedence over project config",
+			Args: []string{workloadName, flags.NamespaceFlagName, defaultNamespace, flags.TypeFlagName, "worker", flags.SourceImageFlagName, "registry.example/other-source", flags.DryRunFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/project.yaml"
+				return ctx, nil
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    app.kubernetes.io/part-of: spring-petclinic
+    apps.tanzu.vmware.com/workload-type: worker
+  name: my-workload
+  namespace: default
+spec:
+  serviceAccountName: my-service-account
+  source:
+    image: registry.example/other-source
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "project config keeps git source of the file",
+			Args: []string{flags.FilePathFlagName, "testdata/workload.yaml", flags.NamespaceFlagName, defaultNamespace, flags.DryRunFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/project.yaml"
+				return ctx, nil
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    app.kubernetes.io/part-of: spring-petclinic
+    apps.tanzu.vmware.com/workload-type: web
+  name: spring-petclinic
+  namespace: default
+spec:
+  env:
+  - name: SPRING_PROFILES_ACTIVE
+    value: mysql
+  resources:
+    limits:
+      cpu: 500m
+      memory: 1Gi
+    requests:
+      cpu: 100m
+      memory: 1Gi
+  serviceAccountName: my-service-account
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://github.com/spring-projects/spring-petclinic.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - ignore project config",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.NoConfigFlagName, flags.DryRunFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/project.yaml"
+				return ctx, nil
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  name: my-workload
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "create - invalid project config",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.DryRunFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/invalid-project.yaml"
+				return ctx, nil
+			},
+			ShouldError: true,
+		},
 		{
 			Name: "create - wait error for false condition",
 			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type=web", flags.LabelFlagName, "apps.tanzu.vmware.com/workload-type-", flags.YesFlagName, flags.WaitFlagName},
@@ -1399,7 +1529,7 @@ Workload "my-workload" is ready
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, true).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, true, "", logs.TailOptions{}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -2457,6 +2587,456 @@ Create workload:
 Created workload "spring-petclinic"
 `,
 		},
+		{
+			Name: "multiple workloads from file",
+			Args: []string{flags.FilePathFlagName, "testdata/workloads.yaml", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.Namespace(defaultNamespace)
+						d.AddLabel(apis.AppPartOfLabelName, "spring-petclinic")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "develop",
+								},
+							},
+						})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:    "spring-petclinic",
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic-api",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:    "spring-petclinic",
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic-api.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Update workload "spring-petclinic":
+...
+ 10, 10   |spec:
+ 11, 11   |  source:
+ 12, 12   |    git:
+ 13, 13   |      ref:
+ 14     - |        branch: develop
+     14 + |        branch: main
+ 15, 15   |      url: https://github.com/spring-projects/spring-petclinic.git
+
+Create workload "spring-petclinic-api":
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic-api
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  source:
+     12 + |    git:
+     13 + |      ref:
+     14 + |        branch: main
+     15 + |      url: https://github.com/spring-projects/spring-petclinic-api.git
+
+Updated workload "spring-petclinic"
+Created workload "spring-petclinic-api"
+`,
+		},
+		{
+			Name: "multiple workloads from file - dry run",
+			Args: []string{flags.FilePathFlagName, "testdata/workloads.yaml", flags.DryRunFlagName},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    app.kubernetes.io/part-of: spring-petclinic
+    apps.tanzu.vmware.com/workload-type: web
+  name: spring-petclinic
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://github.com/spring-projects/spring-petclinic.git
+status:
+  supplyChainRef: {}
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    app.kubernetes.io/part-of: spring-petclinic
+    apps.tanzu.vmware.com/workload-type: web
+  name: spring-petclinic-api
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://github.com/spring-projects/spring-petclinic-api.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "multiple workloads from file - report failures",
+			Args: []string{flags.FilePathFlagName, "testdata/workloads.yaml", flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("create", "Workload", clitesting.InduceFailureOpts{
+					Name: "spring-petclinic-api",
+				}),
+			},
+			ShouldError: true,
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:    "spring-petclinic",
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+					},
+				},
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "spring-petclinic-api",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:    "spring-petclinic",
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic-api.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+					},
+				},
+			},
+			Verify: func(t *testing.T, output string, err error) {
+				for _, expected := range []string{
+					`Created workload "spring-petclinic"`,
+					`Error: failed to create workload "spring-petclinic-api"`,
+				} {
+					if !strings.Contains(output, expected) {
+						t.Errorf("expected output to contain %q, got %q", expected, output)
+					}
+				}
+			},
+		},
+		{
+			Name:        "multiple workloads from file - name is not allowed",
+			Args:        []string{workloadName, flags.FilePathFlagName, "testdata/workloads.yaml", flags.YesFlagName},
+			ShouldError: true,
+		},
+		{
+			Name:        "multiple workloads from file - tail is not allowed",
+			Args:        []string{flags.FilePathFlagName, "testdata/workloads.yaml", flags.TailFlagName, flags.YesFlagName},
+			ShouldError: true,
+		},
+		{
+			Name: "server side apply - update",
+			Args: []string{workloadName, flags.GitBranchFlagName, "develop", flags.ServerSideFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						})
+					}),
+			},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.IgnoreApplyPatches,
+			},
+			ExpectPatches: []clitesting.PatchRef{
+				{
+					Group:     "carto.run",
+					Resource:  "Workload",
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					PatchType: types.ApplyPatchType,
+					Patch:     `{"kind":"Workload","apiVersion":"carto.run/v1alpha1","metadata":{"name":"my-workload","namespace":"default","creationTimestamp":null},"spec":{"source":{"git":{"url":"https://example.com/repo.git","ref":{"branch":"develop"}}}},"status":{"supplyChainRef":{}}}`,
+				},
+			},
+			ExpectOutput: `
+Update workload:
+...
+  7,  7   |spec:
+  8,  8   |  source:
+  9,  9   |    git:
+ 10, 10   |      ref:
+ 11     - |        branch: main
+     11 + |        branch: develop
+ 12, 12   |      url: https://example.com/repo.git
+
+Updated workload "my-workload"
+`,
+		},
+		{
+			Name: "server side apply - create",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServerSideFlagName, flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.IgnoreApplyPatches,
+			},
+			ExpectPatches: []clitesting.PatchRef{
+				{
+					Group:     "carto.run",
+					Resource:  "Workload",
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					PatchType: types.ApplyPatchType,
+					Patch:     `{"kind":"Workload","apiVersion":"carto.run/v1alpha1","metadata":{"name":"my-workload","namespace":"default","creationTimestamp":null},"spec":{"source":{"git":{"url":"https://example.com/repo.git","ref":{"branch":"main"}}}},"status":{"supplyChainRef":{}}}`,
+				},
+			},
+			ExpectOutput: `
+Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  name: my-workload
+      6 + |  namespace: default
+      7 + |spec:
+      8 + |  source:
+      9 + |    git:
+     10 + |      ref:
+     11 + |        branch: main
+     12 + |      url: https://example.com/repo.git
+
+Created workload "my-workload"
+`,
+		},
+		{
+			Name: "server side apply - conflicts",
+			Args: []string{workloadName, flags.GitBranchFlagName, "develop", flags.ServerSideFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						})
+					}),
+			},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("patch", "Workload", clitesting.InduceFailureOpts{
+					Error: apierrs.NewApplyConflict([]metav1.StatusCause{
+						{
+							Type:    metav1.CauseTypeFieldManagerConflict,
+							Message: `conflict with "kapp" using carto.run/v1alpha1`,
+							Field:   ".spec.source.git.ref.branch",
+						},
+					}, `Apply failed with 1 conflict: conflict with "kapp" using carto.run/v1alpha1: .spec.source.git.ref.branch`),
+				}),
+			},
+			ExpectPatches: []clitesting.PatchRef{
+				{
+					Group:     "carto.run",
+					Resource:  "Workload",
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					PatchType: types.ApplyPatchType,
+					Patch:     `{"kind":"Workload","apiVersion":"carto.run/v1alpha1","metadata":{"name":"my-workload","namespace":"default","creationTimestamp":null},"spec":{"source":{"git":{"url":"https://example.com/repo.git","ref":{"branch":"develop"}}}},"status":{"supplyChainRef":{}}}`,
+				},
+			},
+			ShouldError: true,
+			ExpectOutput: `
+Update workload:
+...
+  7,  7   |spec:
+  8,  8   |  source:
+  9,  9   |    git:
+ 10, 10   |      ref:
+ 11     - |        branch: main
+     11 + |        branch: develop
+ 12, 12   |      url: https://example.com/repo.git
+
+Error: conflict applying workload "my-workload", the following fields are managed by another field manager:
+FIELD                         MANAGER
+.spec.source.git.ref.branch   kapp
+To take ownership of these fields, run the command again with --force-conflicts
+`,
+		},
+		{
+			Name: "server side apply - force conflicts",
+			Args: []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ServerSideFlagName, flags.ForceConflictsFlagName, flags.YesFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.IgnoreApplyPatches,
+			},
+			ExpectPatches: []clitesting.PatchRef{
+				{
+					Group:     "carto.run",
+					Resource:  "Workload",
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					PatchType: types.ApplyPatchType,
+					Patch:     `{"kind":"Workload","apiVersion":"carto.run/v1alpha1","metadata":{"name":"my-workload","namespace":"default","creationTimestamp":null},"spec":{"source":{"git":{"url":"https://example.com/repo.git","ref":{"branch":"main"}}}},"status":{"supplyChainRef":{}}}`,
+				},
+			},
+		},
+		{
+			Name: "server side apply - remove field not applied by the cli",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO-", flags.ServerSideFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(corev1.EnvVar{Name: "FOO", Value: "bar"})
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+					},
+				},
+			},
+			ExpectOutput: `
+Update workload:
+...
+  4,  4   |metadata:
+  5,  5   |  name: my-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8     - |  env:
+  9     - |  - name: FOO
+ 10     - |    value: bar
+ 11,  8   |  image: ubuntu:bionic
+
+WARNING: fields removed from workload "my-workload" are not managed by tanzu-apps-cli, updating the workload instead of applying it
+Updated workload "my-workload"
+`,
+		},
+		{
+			Name: "server side apply - remove field applied by the cli",
+			Args: []string{workloadName, flags.EnvFlagName, "FOO-", flags.ServerSideFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.ManagedFields(metav1.ManagedFieldsEntry{
+							Manager:    commands.WorkloadFieldManager,
+							Operation:  metav1.ManagedFieldsOperationApply,
+							APIVersion: "carto.run/v1alpha1",
+							FieldsType: "FieldsV1",
+							FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:env":{"k:{\"name\":\"FOO\"}":{".":{},"f:name":{},"f:value":{}}},"f:image":{}}}`)},
+						})
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+						d.Env(corev1.EnvVar{Name: "FOO", Value: "bar"})
+					}),
+			},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.IgnoreApplyPatches,
+			},
+			ExpectPatches: []clitesting.PatchRef{
+				{
+					Group:     "carto.run",
+					Resource:  "Workload",
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					PatchType: types.ApplyPatchType,
+					Patch:     `{"kind":"Workload","apiVersion":"carto.run/v1alpha1","metadata":{"name":"my-workload","namespace":"default","creationTimestamp":null},"spec":{"image":"ubuntu:bionic"},"status":{"supplyChainRef":{}}}`,
+				},
+			},
+			ExpectOutput: `
+Update workload:
+...
+  4,  4   |metadata:
+  5,  5   |  name: my-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8     - |  env:
+  9     - |  - name: FOO
+ 10     - |    value: bar
+ 11,  8   |  image: ubuntu:bionic
+
+Updated workload "my-workload"
+`,
+		},
+		{
+			Name:        "force conflicts requires server side apply",
+			Args:        []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.ForceConflictsFlagName, flags.YesFlagName},
+			ShouldError: true,
+		},
 	}
 
 	table.Run(t, scheme, func(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+// ProjectConfigFileName is the name of the project config file, found in the working directory or
+// one of its parents
+const ProjectConfigFileName = ".tanzu-apps.yaml"
+
+// ProjectConfig holds default values for the workload flags of a project. Flags set on the command
+// line take precedence.
+type ProjectConfig struct {
+	Namespace          string `json:"namespace,omitempty"`
+	App                string `json:"app,omitempty"`
+	Type               string `json:"type,omitempty"`
+	SourceImage        string `json:"source-image,omitempty"`
+	ServiceAccountName string `json:"service-account,omitempty"`
+}
+
+// LoadProjectConfig reads a project config file, unknown keys are an error
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file %q: %w", path, err)
+	}
+	config := &ProjectConfig{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("unable to load config file %q: %w", path, err)
+	}
+	return config, nil
+}
+
+// findProjectConfig walks up from the directory to the root of the filesystem, returning the path
+// of the first project config file found, or an empty string.
+func findProjectConfig(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectConfigFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// projectConfigFile is the config file set with the config flag, or else the one discovered from
+// the working directory
+func projectConfigFile(c *cli.Config) string {
+	if c.ViperConfigFile != "" {
+		return c.ViperConfigFile
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return findProjectConfig(wd)
+}
+
+// projectNamespaceFlag defines the namespace flag of a workload command that does not take workload
+// flags, defaulted from the project config.
+func projectNamespaceFlag(ctx context.Context, cmd *cobra.Command, c *cli.Config, namespace *string) {
+	cli.NamespaceFlag(ctx, cmd, c, namespace)
+	defaultProjectNamespace(cmd, c, namespace)
+}
+
+// defaultProjectNamespace defaults the namespace from the project config before it is defaulted
+// from the kube config, so the workloads created with the project config are found. The namespace
+// flag must already be defined.
+func defaultProjectNamespace(cmd *cobra.Command, c *cli.Config, namespace *string) {
+	prior := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if path := projectConfigFile(c); path != "" && !cmd.Flags().Changed(cli.StripDash(flags.NamespaceFlagName)) {
+			config, err := LoadProjectConfig(path)
+			if err != nil {
+				return err
+			}
+			if config.Namespace != "" {
+				*namespace = config.Namespace
+			}
+		}
+		if prior != nil {
+			return prior(cmd, args)
+		}
+		return nil
+	}
+}
+
+// ApplyProjectConfig defaults the options not set by flags with the values of the project config.
+// The source image is only defaulted for workloads without a git repo, an image or a source image.
+func (opts *WorkloadOptions) ApplyProjectConfig(ctx context.Context, c *cli.Config, cmd *cobra.Command) error {
+	if opts.NoConfig {
+		return nil
+	}
+	path := projectConfigFile(c)
+	if path == "" {
+		return nil
+	}
+	config, err := LoadProjectConfig(path)
+	if err != nil {
+		return err
+	}
+
+	type flagDefault struct {
+		flag  string
+		field *string
+		value string
+	}
+	defaults := []flagDefault{
+		{flag: flags.NamespaceFlagName, field: &opts.Namespace, value: config.Namespace},
+		{flag: flags.AppFlagName, field: &opts.App, value: config.App},
+		{flag: flags.TypeFlagName, field: &opts.Type, value: config.Type},
+		{flag: flags.ServiceAccountFlagName, field: &opts.ServiceAccountName, value: config.ServiceAccountName},
+	}
+	// the source image depends on the source of the workload, it is defaulted when the options are
+	// applied to the workload
+	defaults = append(defaults, flagDefault{flag: flags.SourceImageFlagName, field: &opts.ProjectSourceImage, value: config.SourceImage})
+	for _, d := range defaults {
+		if d.value != "" && !cmd.Flags().Changed(cli.StripDash(d.flag)) {
+			*d.field = d.value
+		}
+	}
+	return nil
+}
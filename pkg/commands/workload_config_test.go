@@ -0,0 +1,169 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/commands"
+)
+
+func TestLoadProjectConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		expected    *commands.ProjectConfig
+		shouldError bool
+	}{
+		{
+			name: "project config",
+			file: "testdata/project.yaml",
+			expected: &commands.ProjectConfig{
+				Namespace:          "my-namespace",
+				App:                "spring-petclinic",
+				Type:               "web",
+				SourceImage:        "registry.example/spring-petclinic-source",
+				ServiceAccountName: "my-service-account",
+			},
+		},
+		{
+			name:        "unknown key",
+			file:        "testdata/invalid-project.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "missing file",
+			file:        "testdata/missing.yaml",
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := commands.LoadProjectConfig(test.file)
+			if (err == nil) == test.shouldError {
+				t.Errorf("LoadProjectConfig() shouldErr %t, got %v", test.shouldError, err)
+			} else if test.shouldError {
+				return
+			}
+			if diff := cmp.Diff(test.expected, config); diff != "" {
+				t.Errorf("LoadProjectConfig() (-expected, +actual) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadOptionsApplyProjectConfig(t *testing.T) {
+	project := t.TempDir()
+	nested := filepath.Join(project, "src", "app")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config, err := os.ReadFile("testdata/project.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, commands.ProjectConfigFileName), config, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		dir      string
+		args     []string
+		opts     *commands.WorkloadOptions
+		expected *commands.WorkloadOptions
+	}{
+		{
+			name: "discovered from a parent directory",
+			dir:  nested,
+			opts: &commands.WorkloadOptions{},
+			expected: &commands.WorkloadOptions{
+				Namespace:          "my-namespace",
+				App:                "spring-petclinic",
+				Type:               "web",
+				ProjectSourceImage: "registry.example/spring-petclinic-source",
+				ServiceAccountName: "my-service-account",
+			},
+		},
+		{
+			name: "flags take precedence",
+			dir:  project,
+			args: []string{"--type", "worker"},
+			opts: &commands.WorkloadOptions{},
+			expected: &commands.WorkloadOptions{
+				Namespace:          "my-namespace",
+				App:                "spring-petclinic",
+				Type:               "worker",
+				ProjectSourceImage: "registry.example/spring-petclinic-source",
+				ServiceAccountName: "my-service-account",
+			},
+		},
+		{
+			name:     "not found",
+			dir:      filepath.Dir(project),
+			opts:     &commands.WorkloadOptions{},
+			expected: &commands.WorkloadOptions{},
+		},
+		{
+			name: "disabled",
+			dir:  nested,
+			opts: &commands.WorkloadOptions{
+				NoConfig: true,
+			},
+			expected: &commands.WorkloadOptions{
+				NoConfig: true,
+			},
+		},
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := os.Chdir(test.dir); err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			c := cli.NewDefaultConfig("test", runtime.NewScheme())
+			cmd := &cobra.Command{}
+			cmd.Flags().StringVar(&test.opts.Type, "type", "", "")
+			if err := cmd.Flags().Parse(test.args); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.opts.ApplyProjectConfig(ctx, c, cmd); err != nil {
+				t.Errorf("ApplyProjectConfig() unexpected error %v", err)
+			}
+			if diff := cmp.Diff(test.expected, test.opts); diff != "" {
+				t.Errorf("ApplyProjectConfig() (-expected, +actual) = %s", diff)
+			}
+		})
+	}
+}
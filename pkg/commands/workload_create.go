@@ -25,6 +25,7 @@ import (
 
 	"github.com/spf13/cobra"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +42,9 @@ import (
 
 type WorkloadCreateOptions struct {
 	WorkloadOptions
+
+	Interactive bool
+	From        string
 }
 
 var (
@@ -50,16 +54,97 @@ var (
 )
 
 func (opts *WorkloadCreateOptions) Validate(ctx context.Context) validation.FieldErrors {
-	return opts.WorkloadOptions.Validate(ctx)
+	errs := validation.FieldErrors{}
+
+	// the workload is described by at most one of a file, prompts or an existing workload
+	described := []string{}
+	if opts.FilePath != "" {
+		described = append(described, flags.FilePathFlagName)
+	}
+	if opts.Interactive {
+		described = append(described, flags.InteractiveFlagName)
+	}
+	if opts.From != "" {
+		described = append(described, flags.FromFlagName)
+		namespace, name := splitNamespacedName(opts.From, opts.Namespace)
+		if validation.K8sName(namespace, flags.FromFlagName).ToAggregate() != nil || validation.K8sName(name, flags.FromFlagName).ToAggregate() != nil {
+			errs = errs.Also(validation.ErrInvalidValue(opts.From, flags.FromFlagName))
+		}
+	}
+	if len(described) > 1 {
+		errs = errs.Also(validation.ErrMultipleOneOf(described...))
+	}
+
+	for _, err := range opts.WorkloadOptions.Validate(ctx) {
+		// a missing name is prompted for
+		if opts.Interactive && opts.Name == "" && err.Field == cli.NameArgumentName {
+			continue
+		}
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// splitNamespacedName splits a "namespace/name" reference, the namespace defaults when omitted
+func splitNamespacedName(ref, defaultNamespace string) (string, string) {
+	if i := strings.Index(ref, "/"); i != -1 {
+		return ref[:i], ref[i+1:]
+	}
+	return defaultNamespace, ref
+}
+
+// cloneWorkload fetches the workload to base the new workload on. The clone keeps the labels,
+// annotations and spec of the existing workload, other metadata and the status are pruned the
+// same way as exporting a workload.
+func (opts *WorkloadCreateOptions) cloneWorkload(ctx context.Context, c *cli.Config) (*cartov1alpha1.Workload, error) {
+	namespace, name := splitNamespacedName(opts.From, opts.Namespace)
+	source := &cartov1alpha1.Workload{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, source); err != nil {
+		if apierrs.IsNotFound(err) {
+			c.Errorf("Workload %q not found\n", fmt.Sprintf("%s/%s", namespace, name))
+			return nil, cli.SilenceError(err)
+		}
+		return nil, err
+	}
+
+	return &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      source.Labels,
+			Annotations: source.Annotations,
+		},
+		Spec: source.Spec,
+	}, nil
 }
 
 func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	// the output of the command goes to stderr while the logs are tailed as json to stdout
+	out, tailConfig := opts.OutputConfigs(c)
+	c = out
 	workload := &cartov1alpha1.Workload{}
 
+	if opts.Interactive {
+		if err := opts.AskWorkloadOptions(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	if opts.From != "" {
+		clone, err := opts.cloneWorkload(ctx, c)
+		if err != nil {
+			return err
+		}
+		workload = clone
+	}
+
 	if opts.FilePath != "" {
-		if err := opts.WorkloadOptions.LoadInputWorkload(c.Stdin, workload); err != nil {
+		fileWorkloads, err := opts.WorkloadOptions.LoadInputWorkloads(c.Stdin)
+		if err != nil {
 			return err
 		}
+		if len(fileWorkloads) > 1 {
+			return opts.WorkloadOptions.ApplyWorkloads(ctx, c, fileWorkloads, true)
+		}
+		workload = fileWorkloads[0]
 	}
 
 	if opts.Name != "" {
@@ -103,6 +188,9 @@ func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 	}
 
 	if opts.DryRun {
+		if err := opts.PrintLocalSourceFiles(c); err != nil {
+			return err
+		}
 		cli.DryRunResource(ctx, workload, workload.GetGroupVersionKind())
 		return nil
 	}
@@ -119,7 +207,7 @@ func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 		return err
 	}
 
-	anyTail := opts.Tail || opts.TailTimestamps
+	anyTail := opts.anyTail()
 	if (opts.Yes || okToCreate) && (opts.Wait || anyTail) {
 		c.Infof("Waiting for workload %q to become ready...\n", opts.Name)
 
@@ -140,7 +228,7 @@ func (opts *WorkloadCreateOptions) Exec(ctx context.Context, c *cli.Config) erro
 					panic(err)
 				}
 				containers := []string{}
-				return logs.Tail(ctx, c, opts.Namespace, selector, containers, time.Second, opts.TailTimestamps)
+				return logs.Tail(ctx, tailConfig, opts.Namespace, selector, containers, time.Second, opts.TailTimestamps, opts.TailOutput, logs.TailOptions{})
 			})
 		}
 
@@ -182,6 +270,8 @@ Workload configuration options include:
 			fmt.Sprintf("%s workload create my-workload %s https://example.com/my-workload.git", c.Name, flags.GitRepoFlagName),
 			fmt.Sprintf("%s workload create my-workload %s . %s registry.example/repository:tag", c.Name, flags.LocalPathFlagName, flags.SourceImageFlagName),
 			fmt.Sprintf("%s workload create %s workload.yaml", c.Name, flags.FilePathFlagName),
+			fmt.Sprintf("%s workload create %s", c.Name, flags.InteractiveFlagName),
+			fmt.Sprintf("%s workload create my-feature-workload %s default/my-workload %s feature", c.Name, flags.FromFlagName, flags.GitBranchFlagName),
 		}, "\n"),
 		PreRunE: cli.ValidateE(ctx, opts),
 		RunE:    cli.ExecE(ctx, c, opts),
@@ -193,6 +283,8 @@ Workload configuration options include:
 
 	// Define common flags
 	opts.DefineFlags(ctx, c, cmd)
+	cmd.Flags().StringVar(&opts.From, cli.StripDash(flags.FromFlagName), "", "existing workload to base the workload on, as `namespace/name`, other flags are layered on top of it")
+	cmd.Flags().BoolVar(&opts.Interactive, cli.StripDash(flags.InteractiveFlagName), false, "prompt for the workload name, type, source, services and resource limits not set by other flags")
 
 	return cmd
 }
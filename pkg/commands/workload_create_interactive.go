@@ -0,0 +1,220 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+const (
+	interactiveSourceGit   = "git repository"
+	interactiveSourceLocal = "local path"
+	interactiveSourceImage = "pre-built image"
+)
+
+// surveyValidator adapts a validation func for a flag to validate the answer of a prompt
+func surveyValidator(validate func(string) validation.FieldErrors) survey.Validator {
+	return func(ans interface{}) error {
+		s, _ := ans.(string)
+		return validate(strings.TrimSpace(s)).ToAggregate()
+	}
+}
+
+// optional skips validating empty answers
+func optional(validate func(string) validation.FieldErrors) func(string) validation.FieldErrors {
+	return func(s string) validation.FieldErrors {
+		if s == "" {
+			return validation.FieldErrors{}
+		}
+		return validate(s)
+	}
+}
+
+// splitAnswer splits a comma separated answer into its trimmed, non-empty values
+func splitAnswer(ans string) []string {
+	values := []string{}
+	for _, v := range strings.Split(ans, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// AskWorkloadOptions prompts for the workload options that were not set by flags. Each answer is
+// validated the same way as the matching flag.
+func (opts *WorkloadCreateOptions) AskWorkloadOptions(ctx context.Context, c *cli.Config) error {
+	stdio := printer.WithSurveyStdio(c.Stdin, c.Stdout, c.Stderr)
+	input := func(prompt *survey.Input, answer *string, validate func(string) validation.FieldErrors) error {
+		if err := survey.AskOne(prompt, answer, stdio, survey.WithValidator(surveyValidator(validate))); err != nil {
+			return err
+		}
+		*answer = strings.TrimSpace(*answer)
+		return nil
+	}
+
+	if opts.Name == "" {
+		if err := input(&survey.Input{
+			Message: "Workload name:",
+		}, &opts.Name, func(s string) validation.FieldErrors {
+			return validation.K8sName(s, cli.NameArgumentName)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if opts.App == "" {
+		if err := input(&survey.Input{
+			Message: "Application the workload is part of:",
+			Default: opts.Name,
+		}, &opts.App, func(s string) validation.FieldErrors {
+			return validation.K8sLabelValue(s, flags.AppFlagName)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if opts.Type == "" {
+		// suggest the types selected by the supply chains on the cluster, when they can be listed
+		types, err := completion.WorkloadTypes(ctx, c)
+		if err != nil || len(types) == 0 {
+			if err := input(&survey.Input{
+				Message: "Workload type:",
+				Default: completion.DefaultWorkloadType,
+			}, &opts.Type, func(s string) validation.FieldErrors {
+				return validation.K8sLabelValue(s, flags.TypeFlagName)
+			}); err != nil {
+				return err
+			}
+		} else {
+			prompt := &survey.Select{
+				Message: "Workload type:",
+				Options: types,
+			}
+			for _, t := range types {
+				if t == completion.DefaultWorkloadType {
+					prompt.Default = t
+				}
+			}
+			if err := survey.AskOne(prompt, &opts.Type, stdio); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.GitRepo == "" && opts.LocalPath == "" && opts.SourceImage == "" && opts.Image == "" {
+		kind := ""
+		if err := survey.AskOne(&survey.Select{
+			Message: "Source code for the workload:",
+			Options: []string{interactiveSourceGit, interactiveSourceLocal, interactiveSourceImage},
+		}, &kind, stdio); err != nil {
+			return err
+		}
+		required := func(field string) func(string) validation.FieldErrors {
+			return func(s string) validation.FieldErrors {
+				if s == "" {
+					return validation.ErrMissingField(field)
+				}
+				return validation.FieldErrors{}
+			}
+		}
+		switch kind {
+		case interactiveSourceGit:
+			if err := input(&survey.Input{
+				Message: "Git repository url:",
+			}, &opts.GitRepo, required(flags.GitRepoFlagName)); err != nil {
+				return err
+			}
+			if opts.GitBranch == "" && opts.GitTag == "" && opts.GitCommit == "" {
+				if err := input(&survey.Input{
+					Message: "Git branch:",
+					Default: "main",
+				}, &opts.GitBranch, required(flags.GitBranchFlagName)); err != nil {
+					return err
+				}
+			}
+		case interactiveSourceLocal:
+			if err := input(&survey.Input{
+				Message: "Local path to the source code:",
+				Default: ".",
+			}, &opts.LocalPath, required(flags.LocalPathFlagName)); err != nil {
+				return err
+			}
+			if err := input(&survey.Input{
+				Message: "Image repository to publish the source code to:",
+				Default: opts.ProjectSourceImage,
+			}, &opts.SourceImage, required(flags.SourceImageFlagName)); err != nil {
+				return err
+			}
+		case interactiveSourceImage:
+			if err := input(&survey.Input{
+				Message: "Pre-built image:",
+			}, &opts.Image, required(flags.ImageFlagName)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(opts.ServiceRefs) == 0 {
+		refs := ""
+		if err := input(&survey.Input{
+			Message: "Services to bind (optional):",
+			Help:    "comma separated list of \"object-ref-name=apiVersion:kind:service-ref-name\" pairs",
+		}, &refs, func(s string) validation.FieldErrors {
+			return validation.DeletableKeyObjectReferences(splitAnswer(s), flags.ServiceRefFlagName)
+		}); err != nil {
+			return err
+		}
+		opts.ServiceRefs = splitAnswer(refs)
+	}
+
+	if opts.LimitCPU == "" {
+		if err := input(&survey.Input{
+			Message: "CPU limit (optional):",
+			Help:    "the maximum amount of cpu allowed, in CPU cores (500m = .5 cores)",
+		}, &opts.LimitCPU, optional(func(s string) validation.FieldErrors {
+			return validation.Quantity(s, flags.LimitCPUFlagName)
+		})); err != nil {
+			return err
+		}
+	}
+	if opts.LimitMemory == "" {
+		if err := input(&survey.Input{
+			Message: "Memory limit (optional):",
+			Help:    "the maximum amount of memory allowed, in bytes (500Mi = 500MiB = 500 * 1024 * 1024)",
+		}, &opts.LimitMemory, optional(func(s string) validation.FieldErrors {
+			return validation.Quantity(s, flags.LimitMemoryFlagName)
+		})); err != nil {
+			return err
+		}
+	}
+
+	// the answers are validated together, like flags
+	if err := opts.WorkloadOptions.Validate(ctx).ToAggregate(); err != nil {
+		return err
+	}
+	return nil
+}
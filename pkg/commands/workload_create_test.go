@@ -83,6 +83,113 @@ func TestWorkloadCreateOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidArrayValue("FOO", flags.BuildEnvFlagName, 0),
 		},
+		{
+			Name: "missing name",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("", cli.NameArgumentName),
+		},
+		{
+			Name: "interactive without name",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+				},
+				Interactive: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "interactive with invalid name",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "My-Resource",
+				},
+				Interactive: true,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("My-Resource", cli.NameArgumentName),
+		},
+		{
+			Name: "interactive with invalid options",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Env:       []string{"FOO"},
+				},
+				Interactive: true,
+			},
+			ExpectFieldErrors: validation.ErrInvalidArrayValue("FOO", flags.EnvFlagName, 0),
+		},
+		{
+			Name: "interactive with file",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					FilePath:  "workload.yaml",
+				},
+				Interactive: true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.FilePathFlagName, flags.InteractiveFlagName),
+		},
+		{
+			Name: "from",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				From: "dev/my-workload",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "from in namespace",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				From: "my-workload",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid from",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+				},
+				From: "dev/my/workload",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("dev/my/workload", flags.FromFlagName),
+		},
+		{
+			Name: "from with file",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					FilePath:  "workload.yaml",
+				},
+				From: "dev/my-workload",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.FilePathFlagName, flags.FromFlagName),
+		},
+		{
+			Name: "from without name",
+			Validatable: &commands.WorkloadCreateOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+				},
+				From: "dev/my-workload",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("", cli.NameArgumentName),
+		},
 	}
 
 	table.Run(t)
@@ -136,6 +243,199 @@ spec:
       url: https://example.com/repo.git
 status:
   supplyChainRef: {}
+`,
+		},
+		{
+			Name: "dry run with local source",
+			Args: []string{workloadName, flags.LocalPathFlagName, "testdata/local-source", flags.SourceImageFlagName, "example.com/hello:source", flags.DryRunFlagName, flags.YesFlagName},
+			ExpectOutput: `
+Source files in "testdata/local-source" to publish:
+  hello.txt (6B)
+1 files, 6B total
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  name: my-workload
+  namespace: default
+spec:
+  source:
+    image: example.com/hello:source
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "interactive with all options set by flags",
+			Args: []string{workloadName, flags.InteractiveFlagName, flags.AppFlagName, "my-app", flags.TypeFlagName, "web",
+				flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch,
+				flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db",
+				flags.LimitCPUFlagName, "500m", flags.LimitMemoryFlagName, "1Gi", flags.DryRunFlagName},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    app.kubernetes.io/part-of: my-app
+    apps.tanzu.vmware.com/workload-type: web
+  name: my-workload
+  namespace: default
+spec:
+  resources:
+    limits:
+      cpu: 500m
+      memory: 1Gi
+  serviceClaims:
+  - name: database
+    ref:
+      apiVersion: services.tanzu.vmware.com/v1alpha1
+      kind: PostgreSQL
+      name: my-prod-db
+  source:
+    git:
+      ref:
+        branch: main
+      url: https://example.com/repo.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name: "dry run template",
+			Args: []string{flags.FilePathFlagName, "testdata/workload-template.yaml", flags.VarFileFlagName, "testdata/template.vars", flags.VarFlagName, "BRANCH=fix", flags.DryRunFlagName},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  creationTimestamp: null
+  labels:
+    app.kubernetes.io/part-of: spring-petclinic
+    apps.tanzu.vmware.com/workload-type: web
+  name: spring-petclinic-feature
+  namespace: default
+spec:
+  source:
+    git:
+      ref:
+        branch: fix
+      url: https://github.com/spring-projects/spring-petclinic.git
+status:
+  supplyChainRef: {}
+`,
+		},
+		{
+			Name:        "template with unresolved variables",
+			Args:        []string{flags.FilePathFlagName, "testdata/workload-template.yaml", flags.VarFlagName, "BRANCH=fix", flags.YesFlagName},
+			ShouldError: true,
+			Verify: func(t *testing.T, output string, err error) {
+				msg := `unable to render file "testdata/workload-template.yaml": unresolved variables: NAME`
+				if err == nil || err.Error() != msg {
+					t.Errorf("expected error %q, got %v", msg, err)
+				}
+			},
+		},
+		{
+			Name: "create from existing workload",
+			Args: []string{"my-feature-workload", flags.FromFlagName, "dev/my-workload", flags.GitBranchFlagName, "feature", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:       "dev",
+						Name:            workloadName,
+						ResourceVersion: "999",
+						Generation:      2,
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+						Annotations: map[string]string{
+							"example.com/owner": "team-a",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "bar"},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: gitBranch,
+								},
+							},
+						},
+					},
+					Status: cartov1alpha1.WorkloadStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   cartov1alpha1.WorkloadConditionReady,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				},
+			},
+			ExpectCreates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      "my-feature-workload",
+						Labels: map[string]string{
+							apis.WorkloadTypeLabelName: "web",
+						},
+						Annotations: map[string]string{
+							"example.com/owner": "team-a",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "bar"},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: gitRepo,
+								Ref: cartov1alpha1.GitRef{
+									Branch: "feature",
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Create workload:
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  annotations:
+      6 + |    example.com/owner: team-a
+      7 + |  labels:
+      8 + |    apps.tanzu.vmware.com/workload-type: web
+      9 + |  name: my-feature-workload
+     10 + |  namespace: default
+     11 + |spec:
+     12 + |  env:
+     13 + |  - name: FOO
+     14 + |    value: bar
+     15 + |  source:
+     16 + |    git:
+     17 + |      ref:
+     18 + |        branch: feature
+     19 + |      url: https://example.com/repo.git
+
+Created workload "my-feature-workload"
+`,
+		},
+		{
+			Name:        "create from missing workload",
+			Args:        []string{"my-feature-workload", flags.FromFlagName, "dev/my-workload", flags.YesFlagName},
+			ShouldError: true,
+			ExpectOutput: `
+Workload "dev/my-workload" not found
 `,
 		},
 		{
@@ -357,7 +657,7 @@ Workload "my-workload" is ready
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, false, "", logs.TailOptions{}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -432,7 +732,7 @@ Workload "my-workload" is ready
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, true).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, true, "", logs.TailOptions{}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -936,6 +1236,99 @@ Create workload:
      33 + |      url: https://github.com/spring-projects/spring-petclinic.git
 
 Created workload "spring-petclinic"
+`,
+		},
+		{
+			Name: "create multiple workloads from file",
+			Args: []string{flags.FilePathFlagName, "testdata/workloads.yaml", flags.YesFlagName},
+			ExpectCreates: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.Namespace(defaultNamespace)
+						d.AddLabel(apis.AppPartOfLabelName, "spring-petclinic")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						})
+					}),
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic-api")
+						d.Namespace(defaultNamespace)
+						d.AddLabel(apis.AppPartOfLabelName, "spring-petclinic")
+						d.AddLabel(apis.WorkloadTypeLabelName, "web")
+					}).
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(&cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic-api.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						})
+					}),
+			},
+			ExpectOutput: `
+Create workload "spring-petclinic":
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  source:
+     12 + |    git:
+     13 + |      ref:
+     14 + |        branch: main
+     15 + |      url: https://github.com/spring-projects/spring-petclinic.git
+
+Create workload "spring-petclinic-api":
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic-api
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  source:
+     12 + |    git:
+     13 + |      ref:
+     14 + |        branch: main
+     15 + |      url: https://github.com/spring-projects/spring-petclinic-api.git
+
+Created workload "spring-petclinic"
+Created workload "spring-petclinic-api"
+`,
+		},
+		{
+			Name: "create multiple workloads from file - workload already exists",
+			Args: []string{flags.FilePathFlagName, "testdata/workloads.yaml", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic-api")
+						d.Namespace(defaultNamespace)
+					}),
+			},
+			ShouldError: true,
+			ExpectOutput: `
+Error: workload "default/spring-petclinic-api" already exists
 `,
 		},
 	}
@@ -19,8 +19,6 @@ package commands
 import (
 	"context"
 	"fmt"
-	"io"
-	"os"
 	"strings"
 	"time"
 
@@ -79,23 +77,35 @@ func (opts *WorkloadDeleteOptions) Validate(_ context.Context) validation.FieldE
 
 func (opts *WorkloadDeleteOptions) Exec(ctx context.Context, c *cli.Config) error {
 	workload := &cartov1alpha1.Workload{}
-	names := opts.Names
+	fileKeys := []client.ObjectKey{}
 
 	if opts.FilePath != "" {
-		fileWorkload := &cartov1alpha1.Workload{}
-
-		if err := opts.loadInputWorkload(c.Stdin, fileWorkload); err != nil {
+		fileWorkloads, err := loadInputWorkloads(opts.FilePath, c.Stdin, nil)
+		if err != nil {
 			return err
 		}
 
-		if fileWorkload.Namespace != "" && !cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.NamespaceFlagName)) {
-			opts.Namespace = fileWorkload.Namespace
+		namespaceChanged := cli.CommandFromContext(ctx).Flags().Changed(cli.StripDash(flags.NamespaceFlagName))
+		if len(fileWorkloads) == 1 && fileWorkloads[0].Namespace != "" && !namespaceChanged {
+			opts.Namespace = fileWorkloads[0].Namespace
 		}
-		if fileWorkload.Name != "" {
-			names = append(names, fileWorkload.Name)
+		for _, fileWorkload := range fileWorkloads {
+			namespace := opts.Namespace
+			if fileWorkload.Namespace != "" && !namespaceChanged {
+				namespace = fileWorkload.Namespace
+			}
+			if fileWorkload.Name != "" {
+				fileKeys = append(fileKeys, client.ObjectKey{Namespace: namespace, Name: fileWorkload.Name})
+			}
 		}
 	}
 
+	keys := []client.ObjectKey{}
+	for _, name := range opts.Names {
+		keys = append(keys, client.ObjectKey{Namespace: opts.Namespace, Name: name})
+	}
+	keys = append(keys, fileKeys...)
+
 	if opts.All {
 		if !opts.Yes {
 			if opts.FilePath == "-" {
@@ -121,8 +131,9 @@ func (opts *WorkloadDeleteOptions) Exec(ctx context.Context, c *cli.Config) erro
 		return nil
 	}
 
-	for _, name := range names {
-		if err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: name}, workload); err != nil {
+	for _, key := range keys {
+		name := key.Name
+		if err := c.Get(ctx, key, workload); err != nil {
 			if apierrs.IsNotFound(err) {
 				c.Infof("Workload %q does not exist\n", name)
 				continue
@@ -159,7 +170,7 @@ func (opts *WorkloadDeleteOptions) Exec(ctx context.Context, c *cli.Config) erro
 			if err := wait.Race(ctx, opts.WaitTimeout, workers); err != nil {
 				if err == context.DeadlineExceeded {
 					c.Printf("%s timeout after %s waiting for %q to be deleted\n", printer.Serrorf("Error:"), opts.WaitTimeout, name)
-					c.Infof("To view status run: tanzu apps workload get %s %s %s\n", name, flags.NamespaceFlagName, opts.Namespace)
+					c.Infof("To view status run: tanzu apps workload get %s %s %s\n", name, flags.NamespaceFlagName, key.Namespace)
 					return cli.SilenceError(err)
 				}
 				c.Eprintf("%s %s\n", printer.Serrorf("Error:"), err)
@@ -172,24 +183,6 @@ func (opts *WorkloadDeleteOptions) Exec(ctx context.Context, c *cli.Config) erro
 	return nil
 }
 
-func (opts *WorkloadDeleteOptions) loadInputWorkload(input io.Reader, workload *cartov1alpha1.Workload) error {
-	var in io.Reader
-
-	f, err := os.Open(opts.FilePath)
-	in = f
-	if f == nil && opts.FilePath == "-" {
-		in = input
-	} else if err != nil {
-		return fmt.Errorf("unable to open file %q: %w", opts.FilePath, err)
-	}
-	defer f.Close()
-
-	if err := workload.Load(in); err != nil {
-		return fmt.Errorf("unable to load file %q: %w", opts.FilePath, err)
-	}
-	return nil
-}
-
 func NewWorkloadDeleteCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	opts := &WorkloadDeleteOptions{}
 
@@ -215,13 +208,13 @@ registry.
 		cli.NamesArg(&opts.Names),
 	)
 
-	cli.NamespaceFlag(ctx, cmd, c, &opts.Namespace)
+	projectNamespaceFlag(ctx, cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.All, cli.StripDash(flags.AllFlagName), false, "delete all workloads within the namespace")
 	cmd.Flags().BoolVar(&opts.Wait, cli.StripDash(flags.WaitFlagName), false, "waits for workload to be deleted")
 	cmd.Flags().DurationVar(&opts.WaitTimeout, cli.StripDash(flags.WaitTimeoutFlagName), 1*time.Minute, "timeout for workload to be deleted when waiting")
 	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.WaitTimeoutFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
 	cmd.Flags().BoolVarP(&opts.Yes, cli.StripDash(flags.YesFlagName), "y", false, "accept all prompts")
-	cmd.Flags().StringVarP(&opts.FilePath, cli.StripDash(flags.FilePathFlagName), "f", "", "`file path` containing the description of a single workload, other flags are layered on top of this resource. Use value \"-\" to read from stdin")
+	cmd.Flags().StringVarP(&opts.FilePath, cli.StripDash(flags.FilePathFlagName), "f", "", "`file path` containing the description of one or more workloads, or a directory of such files, to delete. Use value \"-\" to read from stdin")
 
 	return cmd
 }
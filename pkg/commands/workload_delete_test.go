@@ -621,6 +621,63 @@ Deleted workload "spring-petclinic"
 			ExpectOutput: `
 Deleted workload "test-workload"
 Deleted workload "spring-petclinic"
+`,
+		},
+		{
+			Name: "delete workloads from file with multiple workloads",
+			Args: []string{flags.FilePathFlagName, "testdata/workloads.yaml", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.Namespace(defaultNamespace)
+					}),
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic-api")
+						d.Namespace(defaultNamespace)
+					}),
+			},
+			ExpectDeletes: []clitesting.DeleteRef{
+				{
+					Group:     "carto.run",
+					Resource:  "Workload",
+					Namespace: defaultNamespace,
+					Name:      "spring-petclinic",
+				},
+				{
+					Group:     "carto.run",
+					Resource:  "Workload",
+					Namespace: defaultNamespace,
+					Name:      "spring-petclinic-api",
+				},
+			},
+			ExpectOutput: `
+Deleted workload "spring-petclinic"
+Deleted workload "spring-petclinic-api"
+`,
+		},
+		{
+			Name: "delete workloads from directory",
+			Args: []string{flags.FilePathFlagName, "testdata/workloads", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				diecartov1alpha1.WorkloadBlank.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name("spring-petclinic")
+						d.Namespace(defaultNamespace)
+					}),
+			},
+			ExpectDeletes: []clitesting.DeleteRef{
+				{
+					Group:     "carto.run",
+					Resource:  "Workload",
+					Namespace: defaultNamespace,
+					Name:      "spring-petclinic",
+				},
+			},
+			ExpectOutput: `
+Workload "spring-petclinic-api" does not exist
+Deleted workload "spring-petclinic"
 `,
 		},
 	}
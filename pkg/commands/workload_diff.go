@@ -0,0 +1,177 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+const (
+	// WorkloadDiffDriftExitCode is the exit code when a workload differs from the cluster
+	WorkloadDiffDriftExitCode = 1
+	// WorkloadDiffErrorExitCode is the exit code when the workloads could not be compared
+	WorkloadDiffErrorExitCode = 2
+)
+
+type WorkloadDiffOptions struct {
+	WorkloadOptions
+}
+
+var (
+	_ validation.Validatable = (*WorkloadDiffOptions)(nil)
+	_ cli.Executable         = (*WorkloadDiffOptions)(nil)
+)
+
+func (opts *WorkloadDiffOptions) Validate(ctx context.Context) validation.FieldErrors {
+	return opts.WorkloadOptions.Validate(ctx)
+}
+
+func (opts *WorkloadDiffOptions) Exec(ctx context.Context, c *cli.Config) error {
+	fileWorkloads := []*cartov1alpha1.Workload{
+		{ObjectMeta: metav1.ObjectMeta{Name: opts.Name}},
+	}
+	if opts.FilePath != "" {
+		var err error
+		if fileWorkloads, err = opts.WorkloadOptions.LoadInputWorkloads(c.Stdin); err != nil {
+			return err
+		}
+		if opts.Name != "" {
+			if len(fileWorkloads) > 1 {
+				// show command usage before error
+				cli.CommandFromContext(ctx).SilenceUsage = false
+				return validation.ErrDisallowedFields(cli.NameArgumentName, "not supported when the file contains multiple workloads").ToAggregate()
+			}
+			fileWorkloads[0].Name = opts.Name
+		}
+	}
+
+	// validate that a namespace and name are provided
+	errs := validation.FieldErrors{}
+	for _, fileWorkload := range fileWorkloads {
+		if fileWorkload.Name == "" {
+			errs = errs.Also(validation.ErrMissingField(cli.NameArgumentName))
+			break
+		}
+	}
+	if opts.Namespace == "" {
+		errs = errs.Also(validation.ErrMissingField(flags.NamespaceFlagName))
+	}
+	if err := errs.ToAggregate(); err != nil {
+		return err
+	}
+
+	changes, err := opts.loadWorkloadChanges(ctx, c, fileWorkloads, false)
+	if err != nil {
+		return err
+	}
+
+	drifted := 0
+	for _, change := range changes {
+		difference, noChange, err := printer.ResourceDiff(change.current, change.workload, c.Scheme)
+		if err != nil {
+			return err
+		}
+		if noChange {
+			c.Infof("Workload %q is unchanged\n", change.workload.Name)
+			continue
+		}
+		drifted++
+		if change.current == nil {
+			c.Printf("Create workload %q:\n", change.workload.Name)
+		} else {
+			c.Printf("Update workload %q:\n", change.workload.Name)
+		}
+		c.Printf("%s\n", difference)
+	}
+
+	if drifted != 0 {
+		// the diff has already been printed, exit with an error code so scripts can detect drift
+		err := fmt.Errorf("%d of %d workloads differ from the cluster", drifted, len(changes))
+		return cli.WithExitCode(WorkloadDiffDriftExitCode, cli.SilenceError(err))
+	}
+	return nil
+}
+
+func NewWorkloadDiffCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &WorkloadDiffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show differences between the desired and the current configuration of a workload",
+		Long: strings.TrimSpace(`
+Show the changes that apply would make to a new or existing workload, without writing anything to
+the cluster.
+
+The desired workload is computed exactly like the apply command does, layering the flags on top of
+the file and the workload on the cluster. Like kubectl diff, the exit status allows scripts to
+detect drift between a workload definition and the cluster:
+
+  0  no differences were found
+  1  differences were found
+  >1 the command failed, the workloads could not be compared
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s workload diff %s workload.yaml", c.Name, flags.FilePathFlagName),
+			fmt.Sprintf("%s workload diff my-workload %s main", c.Name, flags.GitBranchFlagName),
+		}, "\n"),
+		PreRunE:           cli.ValidateE(ctx, opts),
+		RunE:              cli.ExecE(ctx, c, opts),
+		ValidArgsFunction: completion.SuggestWorkloadNames(ctx, c),
+	}
+
+	cli.Args(cmd,
+		cli.OptionalNameArg(&opts.Name),
+	)
+
+	opts.defineWorkloadFlags(ctx, c, cmd)
+
+	// errors exit with a code greater than the code for differences, so drift is not taken for a failure
+	cmd.Args = withErrorExitCode(WorkloadDiffErrorExitCode, cmd.Args)
+	cmd.PreRunE = withErrorExitCode(WorkloadDiffErrorExitCode, cmd.PreRunE)
+	cmd.RunE = withErrorExitCode(WorkloadDiffErrorExitCode, cmd.RunE)
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return cli.WithExitCode(WorkloadDiffErrorExitCode, err)
+	})
+
+	return cmd
+}
+
+func withErrorExitCode(code int, f func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	if f == nil {
+		return nil
+	}
+	return func(cmd *cobra.Command, args []string) error {
+		err := f(cmd, args)
+		if err == nil || errors.Is(err, cli.ExitCodeError) {
+			return err
+		}
+		return cli.WithExitCode(code, err)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package commands_test
+
+import (
+	"testing"
+
+	diemetav1 "dies.dev/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/commands"
+	diecartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/dies/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+func TestWorkloadDiffOptionsValidate(t *testing.T) {
+	table := clitesting.ValidatableTestSuite{
+		{
+			Name: "valid options",
+			Validatable: &commands.WorkloadDiffOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Env:       []string{"FOO=bar"},
+				},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid options",
+			Validatable: &commands.WorkloadDiffOptions{
+				WorkloadOptions: commands.WorkloadOptions{
+					Namespace: "default",
+					Name:      "my-resource",
+					Env:       []string{"FOO"},
+				},
+			},
+			ExpectFieldErrors: validation.ErrInvalidArrayValue("FOO", flags.EnvFlagName, 0),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestWorkloadDiffCommand(t *testing.T) {
+	defaultNamespace := "default"
+	workloadName := "my-workload"
+	gitRepo := "https://example.com/repo.git"
+	gitBranch := "main"
+
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+
+	parent := diecartov1alpha1.WorkloadBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name(workloadName)
+			d.Namespace(defaultNamespace)
+		}).
+		SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+			d.Source(&cartov1alpha1.Source{
+				Git: &cartov1alpha1.GitSource{
+					URL: gitRepo,
+					Ref: cartov1alpha1.GitRef{
+						Branch: gitBranch,
+					},
+				},
+			})
+		})
+
+	petclinic := diecartov1alpha1.WorkloadBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name("spring-petclinic")
+			d.Namespace(defaultNamespace)
+			d.AddLabel(apis.AppPartOfLabelName, "spring-petclinic")
+			d.AddLabel(apis.WorkloadTypeLabelName, "web")
+		}).
+		SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+			d.Source(&cartov1alpha1.Source{
+				Git: &cartov1alpha1.GitSource{
+					URL: "https://github.com/spring-projects/spring-petclinic.git",
+					Ref: cartov1alpha1.GitRef{
+						Branch: "main",
+					},
+				},
+			})
+		})
+
+	expectExitCode := func(code int) func(t *testing.T, output string, err error) {
+		return func(t *testing.T, output string, err error) {
+			if expected, actual := code, cli.ExitCode(err); expected != actual {
+				t.Errorf("expected exit code %d, got %d for error %v", expected, actual, err)
+			}
+		}
+	}
+
+	table := clitesting.CommandTestSuite{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+			Verify:      expectExitCode(commands.WorkloadDiffErrorExitCode),
+		},
+		{
+			Name: "get failed",
+			Args: []string{workloadName, flags.GitBranchFlagName, gitBranch},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Workload"),
+			},
+			ShouldError: true,
+			Verify:      expectExitCode(commands.WorkloadDiffErrorExitCode),
+		},
+		{
+			Name:         "unchanged workload",
+			Args:         []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch},
+			GivenObjects: []client.Object{parent},
+			ExpectOutput: `
+Workload "my-workload" is unchanged
+`,
+		},
+		{
+			Name:         "changed workload",
+			Args:         []string{workloadName, flags.GitBranchFlagName, "develop", flags.EnvFlagName, "FOO=bar"},
+			GivenObjects: []client.Object{parent},
+			ShouldError:  true,
+			ExpectOutput: `
+Update workload "my-workload":
+...
+  4,  4   |metadata:
+  5,  5   |  name: my-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+      8 + |  env:
+      9 + |  - name: FOO
+     10 + |    value: bar
+  8, 11   |  source:
+  9, 12   |    git:
+ 10, 13   |      ref:
+ 11     - |        branch: main
+     14 + |        branch: develop
+ 12, 15   |      url: https://example.com/repo.git
+
+`,
+			Verify: expectExitCode(commands.WorkloadDiffDriftExitCode),
+		},
+		{
+			Name:        "missing workload",
+			Args:        []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch},
+			ShouldError: true,
+			ExpectOutput: `
+Create workload "my-workload":
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  name: my-workload
+      6 + |  namespace: default
+      7 + |spec:
+      8 + |  source:
+      9 + |    git:
+     10 + |      ref:
+     11 + |        branch: main
+     12 + |      url: https://example.com/repo.git
+
+`,
+			Verify: expectExitCode(commands.WorkloadDiffDriftExitCode),
+		},
+		{
+			Name:         "workload from file with name from args",
+			Args:         []string{workloadName, flags.FilePathFlagName, "testdata/workload.yaml", flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch},
+			GivenObjects: []client.Object{parent},
+			ShouldError:  true,
+			Verify:       expectExitCode(commands.WorkloadDiffDriftExitCode),
+		},
+		{
+			Name:         "multiple workloads from file",
+			Args:         []string{flags.FilePathFlagName, "testdata/workloads.yaml"},
+			GivenObjects: []client.Object{petclinic},
+			ShouldError:  true,
+			ExpectOutput: `
+Workload "spring-petclinic" is unchanged
+Create workload "spring-petclinic-api":
+      1 + |---
+      2 + |apiVersion: carto.run/v1alpha1
+      3 + |kind: Workload
+      4 + |metadata:
+      5 + |  labels:
+      6 + |    app.kubernetes.io/part-of: spring-petclinic
+      7 + |    apps.tanzu.vmware.com/workload-type: web
+      8 + |  name: spring-petclinic-api
+      9 + |  namespace: default
+     10 + |spec:
+     11 + |  source:
+     12 + |    git:
+     13 + |      ref:
+     14 + |        branch: main
+     15 + |      url: https://github.com/spring-projects/spring-petclinic-api.git
+
+`,
+			Verify: expectExitCode(commands.WorkloadDiffDriftExitCode),
+		},
+		{
+			Name:         "workload from template",
+			Args:         []string{flags.FilePathFlagName, "testdata/workload-template.yaml", flags.VarFlagName, "NAME=spring-petclinic", flags.VarFlagName, "BRANCH=main"},
+			GivenObjects: []client.Object{petclinic},
+			ExpectOutput: `
+Workload "spring-petclinic" is unchanged
+`,
+		},
+		{
+			Name:        "name is not allowed with multiple workloads from file",
+			Args:        []string{workloadName, flags.FilePathFlagName, "testdata/workloads.yaml"},
+			ShouldError: true,
+			Verify:      expectExitCode(commands.WorkloadDiffErrorExitCode),
+		},
+		{
+			Name:        "write flags are not supported",
+			Args:        []string{workloadName, flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.YesFlagName},
+			ShouldError: true,
+			Verify:      expectExitCode(commands.WorkloadDiffErrorExitCode),
+		},
+	}
+
+	table.Run(t, scheme, commands.NewWorkloadDiffCommand)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+)
+
+func NewWorkloadEnvCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Manage environment variables of a workload",
+		Long: strings.TrimSpace(`
+List, set and unset the environment variables of a workload. Runtime environment variables are set
+on the running application, build environment variables are only available while the workload is
+built.
+`),
+	}
+
+	cmd.AddCommand(NewWorkloadEnvListCommand(ctx, c))
+	cmd.AddCommand(NewWorkloadEnvSetCommand(ctx, c))
+	cmd.AddCommand(NewWorkloadEnvUnsetCommand(ctx, c))
+
+	return cmd
+}
+
+// updateWorkloadEnv changes the environment variables of an existing workload, showing the
+// difference and asking for confirmation like the update command
+func updateWorkloadEnv(ctx context.Context, c *cli.Config, namespace, name string, yes bool, mutate func(workload *cartov1alpha1.Workload)) error {
+	workload := &cartov1alpha1.Workload{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, workload); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Workload %q not found\n", fmt.Sprintf("%s/%s", namespace, name))
+		return cli.SilenceError(err)
+	}
+	currentWorkload := workload.DeepCopy()
+	mutate(workload)
+
+	opts := &WorkloadOptions{
+		Namespace: namespace,
+		Name:      name,
+		Yes:       yes,
+	}
+	_, err := opts.Update(ctx, c, currentWorkload, workload)
+	return err
+}
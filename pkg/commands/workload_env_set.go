@@ -0,0 +1,137 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/parsers"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+const WorkloadEnvArgumentName = "key=value pair(s)"
+
+type WorkloadEnvSetOptions struct {
+	Namespace string
+	Name      string
+
+	Env           []string
+	FromSecret    []string
+	FromConfigMap []string
+	Build         bool
+	Yes           bool
+}
+
+var (
+	_ validation.Validatable = (*WorkloadEnvSetOptions)(nil)
+	_ cli.Executable         = (*WorkloadEnvSetOptions)(nil)
+)
+
+func (opts *WorkloadEnvSetOptions) Validate(ctx context.Context) validation.FieldErrors {
+	errs := validation.FieldErrors{}
+
+	if opts.Namespace == "" {
+		errs = errs.Also(validation.ErrMissingField(flags.NamespaceFlagName))
+	}
+
+	if opts.Name == "" {
+		errs = errs.Also(validation.ErrMissingField(cli.NameArgumentName))
+	} else {
+		errs = errs.Also(validation.K8sName(opts.Name, cli.NameArgumentName))
+	}
+
+	if len(opts.Env) == 0 && len(opts.FromSecret) == 0 && len(opts.FromConfigMap) == 0 {
+		errs = errs.Also(validation.ErrMissingOneOf(WorkloadEnvArgumentName, flags.FromSecretFlagName, flags.FromConfigMapFlagName))
+	}
+
+	errs = errs.Also(validation.EnvVars(opts.Env, WorkloadEnvArgumentName))
+	errs = errs.Also(validation.EnvVarFroms(envVarKeyRefs(opts.FromSecret, parsers.SecretKeyRefEnvVar), flags.FromSecretFlagName))
+	errs = errs.Also(validation.EnvVarFroms(envVarKeyRefs(opts.FromConfigMap, parsers.ConfigMapKeyRefEnvVar), flags.FromConfigMapFlagName))
+
+	return errs
+}
+
+func (opts *WorkloadEnvSetOptions) Exec(ctx context.Context, c *cli.Config) error {
+	return updateWorkloadEnv(ctx, c, opts.Namespace, opts.Name, opts.Yes, func(workload *cartov1alpha1.Workload) {
+		merge := workload.Spec.MergeEnv
+		if opts.Build {
+			merge = workload.Spec.MergeBuildEnv
+		}
+		for _, ev := range opts.Env {
+			merge(parsers.EnvVar(ev))
+		}
+		for _, ev := range opts.FromSecret {
+			merge(parsers.EnvVarFromSecret(ev))
+		}
+		for _, ev := range opts.FromConfigMap {
+			merge(parsers.EnvVarFromConfigMap(ev))
+		}
+	})
+}
+
+func NewWorkloadEnvSetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &WorkloadEnvSetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set environment variables of a workload",
+		Long: strings.TrimSpace(`
+Set runtime environment variables of an existing workload, or build environment variables with
+` + flags.BuildFlagName + `. Variables are given as "key=value" pairs, or read from a key of a Secret or a
+ConfigMap with ` + flags.FromSecretFlagName + ` and ` + flags.FromConfigMapFlagName + `.
+
+The changes to the workload are shown and confirmed before the workload is updated.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s workload env set my-workload LOG_LEVEL=debug", c.Name),
+			fmt.Sprintf("%s workload env set my-workload %s BP_JVM_VERSION=17", c.Name, flags.BuildFlagName),
+			fmt.Sprintf("%s workload env set my-workload %s DB_PASSWORD=my-db-credentials:password", c.Name, flags.FromSecretFlagName),
+		}, "\n"),
+		PreRunE:           cli.ValidateE(ctx, opts),
+		RunE:              cli.ExecE(ctx, c, opts),
+		ValidArgsFunction: completion.SuggestWorkloadEnvNames(ctx, c),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+		cli.Arg{
+			Name:     WorkloadEnvArgumentName,
+			Arity:    -1,
+			Optional: true,
+			Set: func(cmd *cobra.Command, args []string, offset int) error {
+				opts.Env = args[offset:]
+				return nil
+			},
+		},
+	)
+
+	projectNamespaceFlag(ctx, cmd, c, &opts.Namespace)
+	cmd.Flags().StringArrayVar(&opts.FromSecret, cli.StripDash(flags.FromSecretFlagName), []string{}, "environment variable set from a Secret represented as a `\"key=secret:secret-key\" pair` (flag can be used multiple times)")
+	cmd.Flags().StringArrayVar(&opts.FromConfigMap, cli.StripDash(flags.FromConfigMapFlagName), []string{}, "environment variable set from a ConfigMap represented as a `\"key=configmap:configmap-key\" pair` (flag can be used multiple times)")
+	cmd.Flags().BoolVar(&opts.Build, cli.StripDash(flags.BuildFlagName), false, "set build environment variables instead of runtime environment variables")
+	cmd.Flags().BoolVarP(&opts.Yes, cli.StripDash(flags.YesFlagName), "y", false, "accept all prompts")
+
+	return cmd
+}
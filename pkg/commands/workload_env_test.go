@@ -0,0 +1,496 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands_test
+
+import (
+	"strings"
+	"testing"
+
+	diecorev1 "dies.dev/apis/core/v1"
+	diemetav1 "dies.dev/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/commands"
+	diecartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/dies/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+func TestWorkloadEnvCommand(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+
+	table := clitesting.CommandTestSuite{
+		{
+			Name: "empty",
+			Args: []string{},
+			Verify: func(t *testing.T, output string, err error) {
+				if !strings.Contains(output, "Commands:") {
+					t.Errorf("output expected to contain help with nested commands to call")
+				}
+			},
+		},
+	}
+
+	table.Run(t, scheme, commands.NewWorkloadEnvCommand)
+}
+
+func TestWorkloadEnvListOptionsValidate(t *testing.T) {
+	table := clitesting.ValidatableTestSuite{
+		{
+			Name:              "invalid empty",
+			Validatable:       &commands.WorkloadEnvListOptions{},
+			ExpectFieldErrors: validation.ErrMissingField(flags.NamespaceFlagName).Also(validation.ErrMissingField(cli.NameArgumentName)),
+		},
+		{
+			Name: "valid",
+			Validatable: &commands.WorkloadEnvListOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid name",
+			Validatable: &commands.WorkloadEnvListOptions{
+				Namespace: "default",
+				Name:      "my-",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("my-", cli.NameArgumentName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestWorkloadEnvListCommand(t *testing.T) {
+	workloadName := "test-workload"
+	defaultNamespace := "default"
+
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+
+	parent := diecartov1alpha1.WorkloadBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name(workloadName)
+			d.Namespace(defaultNamespace)
+		})
+
+	table := clitesting.CommandTestSuite{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:        "failed to get workload",
+			Args:        []string{workloadName},
+			ShouldError: true,
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Workload"),
+			},
+		},
+		{
+			Name: "missing workload",
+			Args: []string{workloadName},
+			ExpectOutput: `
+Workload "default/test-workload" not found
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "no env",
+			Args: []string{workloadName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+No environment variables found.
+`,
+		},
+		{
+			Name: "list env",
+			Args: []string{workloadName},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.EnvDie("LOG_LEVEL", func(d *diecorev1.EnvVarDie) {
+							d.Value("info")
+						})
+						d.EnvDie("PASSWORD", func(d *diecorev1.EnvVarDie) {
+							d.ValueFrom(&corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+									Key:                  "password",
+								},
+							})
+						})
+						d.Build(&cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "BP_JVM_VERSION", Value: "17"},
+							},
+						})
+					}),
+			},
+			ExpectOutput: `
+NAME             RUNTIME                                BUILD
+LOG_LEVEL        info                                   -
+PASSWORD         ******** (secret my-secret:password)   -
+BP_JVM_VERSION   -                                      17
+`,
+		},
+	}
+
+	table.Run(t, scheme, commands.NewWorkloadEnvListCommand)
+}
+
+func TestWorkloadEnvSetOptionsValidate(t *testing.T) {
+	table := clitesting.ValidatableTestSuite{
+		{
+			Name:        "invalid empty",
+			Validatable: &commands.WorkloadEnvSetOptions{},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrMissingField(flags.NamespaceFlagName),
+				validation.ErrMissingField(cli.NameArgumentName),
+				validation.ErrMissingOneOf(commands.WorkloadEnvArgumentName, flags.FromSecretFlagName, flags.FromConfigMapFlagName),
+			),
+		},
+		{
+			Name: "valid",
+			Validatable: &commands.WorkloadEnvSetOptions{
+				Namespace:     "default",
+				Name:          "my-workload",
+				Env:           []string{"FOO=bar"},
+				FromSecret:    []string{"PASSWORD=my-secret:password"},
+				FromConfigMap: []string{"CONFIG=my-config:config"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid env",
+			Validatable: &commands.WorkloadEnvSetOptions{
+				Namespace:     "default",
+				Name:          "my-workload",
+				Env:           []string{"FOO"},
+				FromSecret:    []string{"PASSWORD=my-secret"},
+				FromConfigMap: []string{"CONFIG"},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidArrayValue("FOO", commands.WorkloadEnvArgumentName, 0),
+				validation.ErrInvalidArrayValue("PASSWORD=secretKeyRef:my-secret", flags.FromSecretFlagName, 0),
+				validation.ErrInvalidArrayValue("CONFIG", flags.FromConfigMapFlagName, 0),
+			),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestWorkloadEnvSetCommand(t *testing.T) {
+	workloadName := "test-workload"
+	defaultNamespace := "default"
+
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+
+	parent := diecartov1alpha1.WorkloadBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name(workloadName)
+			d.Namespace(defaultNamespace)
+		}).
+		SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+			d.Image("ubuntu:bionic")
+			d.EnvDie("LOG_LEVEL", func(d *diecorev1.EnvVarDie) {
+				d.Value("info")
+			})
+		})
+
+	table := clitesting.CommandTestSuite{
+		{
+			Name:        "invalid args",
+			Args:        []string{workloadName},
+			ShouldError: true,
+		},
+		{
+			Name: "missing workload",
+			Args: []string{workloadName, "LOG_LEVEL=debug"},
+			ExpectOutput: `
+Workload "default/test-workload" not found
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "set env",
+			Args: []string{workloadName, "LOG_LEVEL=debug", flags.FromSecretFlagName, "PASSWORD=my-secret:password", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env: []corev1.EnvVar{
+							{Name: "LOG_LEVEL", Value: "debug"},
+							{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+									Key:                  "password",
+								},
+							}},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Update workload:
+...
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8,  8   |  env:
+  9,  9   |  - name: LOG_LEVEL
+ 10     - |    value: info
+     10 + |    value: debug
+     11 + |  - name: PASSWORD
+     12 + |    valueFrom:
+     13 + |      secretKeyRef:
+     14 + |        key: password
+     15 + |        name: my-secret
+ 11, 16   |  image: ubuntu:bionic
+
+Updated workload "test-workload"
+`,
+		},
+		{
+			Name: "set build env",
+			Args: []string{workloadName, "BP_JVM_VERSION=17", flags.BuildFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env: []corev1.EnvVar{
+							{Name: "LOG_LEVEL", Value: "info"},
+						},
+						Build: &cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "BP_JVM_VERSION", Value: "17"},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Update workload:
+...
+  4,  4   |metadata:
+  5,  5   |  name: test-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+      8 + |  build:
+      9 + |    env:
+     10 + |    - name: BP_JVM_VERSION
+     11 + |      value: "17"
+  8, 12   |  env:
+  9, 13   |  - name: LOG_LEVEL
+ 10, 14   |    value: info
+ 11, 15   |  image: ubuntu:bionic
+
+Updated workload "test-workload"
+`,
+		},
+		{
+			Name: "unchanged env",
+			Args: []string{workloadName, "LOG_LEVEL=info", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+Workload is unchanged, skipping update
+`,
+		},
+	}
+
+	table.Run(t, scheme, commands.NewWorkloadEnvSetCommand)
+}
+
+func TestWorkloadEnvUnsetOptionsValidate(t *testing.T) {
+	table := clitesting.ValidatableTestSuite{
+		{
+			Name:        "invalid empty",
+			Validatable: &commands.WorkloadEnvUnsetOptions{},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrMissingField(flags.NamespaceFlagName),
+				validation.ErrMissingField(cli.NameArgumentName),
+				validation.ErrMissingField(commands.WorkloadEnvKeysArgumentName),
+			),
+		},
+		{
+			Name: "valid",
+			Validatable: &commands.WorkloadEnvUnsetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Keys:      []string{"FOO", "BAR"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid keys",
+			Validatable: &commands.WorkloadEnvUnsetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Keys:      []string{"FOO=bar", ""},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidArrayValue("FOO=bar", commands.WorkloadEnvKeysArgumentName, 0),
+				validation.ErrInvalidArrayValue("", commands.WorkloadEnvKeysArgumentName, 1),
+			),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestWorkloadEnvUnsetCommand(t *testing.T) {
+	workloadName := "test-workload"
+	defaultNamespace := "default"
+
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+
+	parent := diecartov1alpha1.WorkloadBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name(workloadName)
+			d.Namespace(defaultNamespace)
+		}).
+		SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+			d.Image("ubuntu:bionic")
+			d.EnvDie("LOG_LEVEL", func(d *diecorev1.EnvVarDie) {
+				d.Value("info")
+			})
+			d.Build(&cartov1alpha1.WorkloadBuild{
+				Env: []corev1.EnvVar{
+					{Name: "LOG_LEVEL", Value: "debug"},
+				},
+			})
+		})
+
+	table := clitesting.CommandTestSuite{
+		{
+			Name: "missing workload",
+			Args: []string{workloadName, "LOG_LEVEL"},
+			ExpectOutput: `
+Workload "default/test-workload" not found
+`,
+			ShouldError: true,
+		},
+		{
+			Name: "unset env",
+			Args: []string{workloadName, "LOG_LEVEL", flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env:   []corev1.EnvVar{},
+						Build: &cartov1alpha1.WorkloadBuild{
+							Env: []corev1.EnvVar{
+								{Name: "LOG_LEVEL", Value: "debug"},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Update workload:
+...
+  8,  8   |  build:
+  9,  9   |    env:
+ 10, 10   |    - name: LOG_LEVEL
+ 11, 11   |      value: debug
+ 12     - |  env:
+ 13     - |  - name: LOG_LEVEL
+ 14     - |    value: info
+ 15, 12   |  image: ubuntu:bionic
+
+Updated workload "test-workload"
+`,
+		},
+		{
+			Name: "unset build env",
+			Args: []string{workloadName, "LOG_LEVEL", flags.BuildFlagName, flags.YesFlagName},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Env: []corev1.EnvVar{
+							{Name: "LOG_LEVEL", Value: "info"},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Update workload:
+...
+  4,  4   |metadata:
+  5,  5   |  name: test-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8     - |  build:
+  9     - |    env:
+ 10     - |    - name: LOG_LEVEL
+ 11     - |      value: debug
+ 12,  8   |  env:
+ 13,  9   |  - name: LOG_LEVEL
+ 14, 10   |    value: info
+ 15, 11   |  image: ubuntu:bionic
+
+Updated workload "test-workload"
+`,
+		},
+	}
+
+	table.Run(t, scheme, commands.NewWorkloadEnvUnsetCommand)
+}
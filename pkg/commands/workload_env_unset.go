@@ -0,0 +1,124 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+const WorkloadEnvKeysArgumentName = "key(s)"
+
+type WorkloadEnvUnsetOptions struct {
+	Namespace string
+	Name      string
+
+	Keys  []string
+	Build bool
+	Yes   bool
+}
+
+var (
+	_ validation.Validatable = (*WorkloadEnvUnsetOptions)(nil)
+	_ cli.Executable         = (*WorkloadEnvUnsetOptions)(nil)
+)
+
+func (opts *WorkloadEnvUnsetOptions) Validate(ctx context.Context) validation.FieldErrors {
+	errs := validation.FieldErrors{}
+
+	if opts.Namespace == "" {
+		errs = errs.Also(validation.ErrMissingField(flags.NamespaceFlagName))
+	}
+
+	if opts.Name == "" {
+		errs = errs.Also(validation.ErrMissingField(cli.NameArgumentName))
+	} else {
+		errs = errs.Also(validation.K8sName(opts.Name, cli.NameArgumentName))
+	}
+
+	if len(opts.Keys) == 0 {
+		errs = errs.Also(validation.ErrMissingField(WorkloadEnvKeysArgumentName))
+	}
+	for i, key := range opts.Keys {
+		if key == "" || strings.Contains(key, "=") {
+			errs = errs.Also(validation.ErrInvalidArrayValue(key, WorkloadEnvKeysArgumentName, i))
+		}
+	}
+
+	return errs
+}
+
+func (opts *WorkloadEnvUnsetOptions) Exec(ctx context.Context, c *cli.Config) error {
+	return updateWorkloadEnv(ctx, c, opts.Namespace, opts.Name, opts.Yes, func(workload *cartov1alpha1.Workload) {
+		for _, key := range opts.Keys {
+			if opts.Build {
+				workload.Spec.RemoveBuildEnv(key)
+			} else {
+				workload.Spec.RemoveEnv(key)
+			}
+		}
+	})
+}
+
+func NewWorkloadEnvUnsetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &WorkloadEnvUnsetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "unset",
+		Short: "Remove environment variables from a workload",
+		Long: strings.TrimSpace(`
+Remove runtime environment variables from an existing workload, or build environment variables with
+` + flags.BuildFlagName + `.
+
+The changes to the workload are shown and confirmed before the workload is updated.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s workload env unset my-workload LOG_LEVEL", c.Name),
+			fmt.Sprintf("%s workload env unset my-workload %s BP_JVM_VERSION", c.Name, flags.BuildFlagName),
+		}, "\n"),
+		PreRunE:           cli.ValidateE(ctx, opts),
+		RunE:              cli.ExecE(ctx, c, opts),
+		ValidArgsFunction: completion.SuggestWorkloadEnvNames(ctx, c),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+		cli.Arg{
+			Name:  WorkloadEnvKeysArgumentName,
+			Arity: -1,
+			Set: func(cmd *cobra.Command, args []string, offset int) error {
+				opts.Keys = args[offset:]
+				return nil
+			},
+		},
+	)
+
+	projectNamespaceFlag(ctx, cmd, c, &opts.Namespace)
+	cmd.Flags().BoolVar(&opts.Build, cli.StripDash(flags.BuildFlagName), false, "remove build environment variables instead of runtime environment variables")
+	cmd.Flags().BoolVarP(&opts.Yes, cli.StripDash(flags.YesFlagName), "y", false, "accept all prompts")
+
+	return cmd
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8swatch "k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/watch"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+type WorkloadEventsOptions struct {
+	Namespace string
+	Name      string
+
+	Since time.Duration
+	Watch bool
+}
+
+var (
+	_ validation.Validatable = (*WorkloadEventsOptions)(nil)
+	_ cli.Executable         = (*WorkloadEventsOptions)(nil)
+)
+
+func (opts *WorkloadEventsOptions) Validate(ctx context.Context) validation.FieldErrors {
+	errs := validation.FieldErrors{}
+
+	if opts.Namespace == "" {
+		errs = errs.Also(validation.ErrMissingField(flags.NamespaceFlagName))
+	}
+
+	if opts.Name == "" {
+		errs = errs.Also(validation.ErrMissingField(cli.NameArgumentName))
+	} else {
+		errs = errs.Also(validation.K8sName(opts.Name, cli.NameArgumentName))
+	}
+
+	if opts.Since < 0 {
+		errs = errs.Also(validation.ErrInvalidValue(opts.Since, flags.SinceFlagName))
+	}
+
+	return errs
+}
+
+func (opts *WorkloadEventsOptions) Exec(ctx context.Context, c *cli.Config) error {
+	workload := &cartov1alpha1.Workload{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.Name}, workload)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			return err
+		}
+		c.Errorf("Workload %q not found\n", fmt.Sprintf("%s/%s", opts.Namespace, opts.Name))
+		return cli.SilenceError(err)
+	}
+
+	objects := workloadInvolvedObjects{}
+	objects.addWorkload(workload)
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(workload.Namespace), client.MatchingLabels{cartov1alpha1.WorkloadLabelName: workload.Name}); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		objects.addPod(&pods.Items[i])
+	}
+
+	events := &corev1.EventList{}
+	if err := c.List(ctx, events, client.InNamespace(workload.Namespace)); err != nil {
+		return err
+	}
+	now := time.Now()
+	filtered := &corev1.EventList{}
+	for _, event := range events.Items {
+		if objects.involves(&event) && opts.isRecent(&event, now) {
+			filtered.Items = append(filtered.Items, event)
+		}
+	}
+	sort.SliceStable(filtered.Items, func(i, j int) bool {
+		return printer.EventTimestamp(&filtered.Items[i]).Time.Before(printer.EventTimestamp(&filtered.Items[j]).Time)
+	})
+
+	if !opts.Watch {
+		if len(filtered.Items) == 0 {
+			c.Infof("No events found for workload.\n")
+			return nil
+		}
+		return printer.InvolvedObjectEventTablePrinter(c.Stdout, filtered, false)
+	}
+
+	// always print the headers, watched events are printed below them
+	if err := printer.InvolvedObjectEventTablePrinter(c.Stdout, filtered, false); err != nil {
+		return err
+	}
+	return opts.streamEvents(ctx, c, workload, objects, events)
+}
+
+// streamEvents prints the events for the workload as they are recorded. The workload and its pods
+// are watched as well, so events for objects stamped or pods started later are not missed.
+func (opts *WorkloadEventsOptions) streamEvents(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload, objects workloadInvolvedObjects, printed *corev1.EventList) error {
+	clientWithWatch, err := watch.GetWatcher(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	watchErrs := make(chan error)
+	eventEvents, err := watchEvents(ctx, watchErrs, clientWithWatch, &corev1.EventList{}, client.InNamespace(workload.Namespace))
+	if err != nil {
+		return err
+	}
+	workloadEvents, err := watchEvents(ctx, watchErrs, clientWithWatch, &cartov1alpha1.WorkloadList{}, client.InNamespace(workload.Namespace))
+	if err != nil {
+		return err
+	}
+	podEvents, err := watchEvents(ctx, watchErrs, clientWithWatch, &corev1.PodList{}, client.InNamespace(workload.Namespace), client.MatchingLabels{cartov1alpha1.WorkloadLabelName: workload.Name})
+	if err != nil {
+		return err
+	}
+
+	// a new watch starts with the events that already exist, they must not be printed again
+	seen := map[string]bool{}
+	for i := range printed.Items {
+		seen[eventRevision(&printed.Items[i])] = true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watchErrs:
+			return err
+		case e := <-workloadEvents:
+			if obj, ok := e.Object.(*cartov1alpha1.Workload); ok && e.Type != k8swatch.Deleted && obj.Name == workload.Name && obj.Namespace == workload.Namespace {
+				objects.addWorkload(obj)
+			}
+		case e := <-podEvents:
+			if obj, ok := e.Object.(*corev1.Pod); ok && e.Type != k8swatch.Deleted {
+				objects.addPod(obj)
+			}
+		case e := <-eventEvents:
+			event, ok := e.Object.(*corev1.Event)
+			if !ok || e.Type == k8swatch.Deleted || !objects.involves(event) || !opts.isRecent(event, time.Now()) {
+				continue
+			}
+			revision := eventRevision(event)
+			if seen[revision] {
+				continue
+			}
+			seen[revision] = true
+			if err := printer.InvolvedObjectEventTablePrinter(c.Stdout, &corev1.EventList{Items: []corev1.Event{*event}}, true); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// isRecent checks if the event was last observed within the since duration, all events are recent
+// without a since duration
+func (opts *WorkloadEventsOptions) isRecent(event *corev1.Event, now time.Time) bool {
+	if opts.Since == 0 {
+		return true
+	}
+	return !printer.EventTimestamp(event).Time.Before(now.Add(-opts.Since))
+}
+
+// eventRevision identifies a version of an event, a recurring event is updated with a new count
+func eventRevision(event *corev1.Event) string {
+	return fmt.Sprintf("%s/%s@%s", event.Namespace, event.Name, event.ResourceVersion)
+}
+
+type involvedObjectKey struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// workloadInvolvedObjects are the workload, the objects stamped by its supply chain and its pods
+type workloadInvolvedObjects map[involvedObjectKey]bool
+
+func (o workloadInvolvedObjects) addWorkload(workload *cartov1alpha1.Workload) {
+	o[involvedObjectKey{Group: cartov1alpha1.SchemeGroupVersion.Group, Kind: "Workload", Namespace: workload.Namespace, Name: workload.Name}] = true
+	for _, resource := range workload.Status.Resources {
+		if resource.StampedRef == nil {
+			continue
+		}
+		namespace := resource.StampedRef.Namespace
+		if namespace == "" {
+			namespace = workload.Namespace
+		}
+		o[involvedObjectKey{Group: apiGroup(resource.StampedRef.APIVersion), Kind: resource.StampedRef.Kind, Namespace: namespace, Name: resource.StampedRef.Name}] = true
+	}
+}
+
+func (o workloadInvolvedObjects) addPod(pod *corev1.Pod) {
+	o[involvedObjectKey{Group: corev1.GroupName, Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}] = true
+}
+
+func (o workloadInvolvedObjects) involves(event *corev1.Event) bool {
+	involved := event.InvolvedObject
+	namespace := involved.Namespace
+	if namespace == "" {
+		namespace = event.Namespace
+	}
+	return o[involvedObjectKey{Group: apiGroup(involved.APIVersion), Kind: involved.Kind, Namespace: namespace, Name: involved.Name}]
+}
+
+// apiGroup is the group of an apiVersion, objects of the same kind and name may exist in several groups
+func apiGroup(apiVersion string) string {
+	gv, _ := schema.ParseGroupVersion(apiVersion)
+	return gv.Group
+}
+
+func NewWorkloadEventsCommand(ctx context.Context, c *cli.Config) *cobra.Command {
+	opts := &WorkloadEventsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Show events for a workload",
+		Long: strings.TrimSpace(`
+Show the events recorded for a workload, the objects stamped by its supply chain and its pods,
+oldest first.
+
+With ` + flags.WatchFlagName + ` new events are displayed as they are recorded until canceled. To cancel,
+press Ctl-c in the shell or kill the process. To only show recent events use ` + flags.SinceFlagName + `.
+`),
+		Example: strings.Join([]string{
+			fmt.Sprintf("%s workload events my-workload", c.Name),
+			fmt.Sprintf("%s workload events my-workload %s %s 10m", c.Name, flags.WatchFlagName, flags.SinceFlagName),
+		}, "\n"),
+		PreRunE:           cli.ValidateE(ctx, opts),
+		RunE:              cli.ExecE(ctx, c, opts),
+		ValidArgsFunction: completion.SuggestWorkloadNames(ctx, c),
+	}
+
+	cli.Args(cmd,
+		cli.NameArg(&opts.Name),
+	)
+
+	projectNamespaceFlag(ctx, cmd, c, &opts.Namespace)
+	cmd.Flags().DurationVar(&opts.Since, cli.StripDash(flags.SinceFlagName), 0, "only show events recorded within the time `duration`, all events are shown by default")
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.SinceFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
+	cmd.Flags().BoolVarP(&opts.Watch, cli.StripDash(flags.WatchFlagName), "w", false, "watch for new events until canceled")
+
+	return cmd
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	diecorev1 "dies.dev/apis/core/v1"
+	diemetav1 "dies.dev/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	watchhelper "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/watch"
+	watchfakes "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/watch/fake"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/commands"
+	diecartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/dies/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+func TestWorkloadEventsOptionsValidate(t *testing.T) {
+	table := clitesting.ValidatableTestSuite{
+		{
+			Name:              "invalid empty",
+			Validatable:       &commands.WorkloadEventsOptions{},
+			ExpectFieldErrors: validation.ErrMissingField(flags.NamespaceFlagName).Also(validation.ErrMissingField(cli.NameArgumentName)),
+		},
+		{
+			Name: "valid",
+			Validatable: &commands.WorkloadEventsOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Since:     time.Minute,
+				Watch:     true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid name",
+			Validatable: &commands.WorkloadEventsOptions{
+				Namespace: "default",
+				Name:      "my-",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue("my-", cli.NameArgumentName),
+		},
+		{
+			Name: "negative since",
+			Validatable: &commands.WorkloadEventsOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Since:     -time.Minute,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue(-time.Minute, flags.SinceFlagName),
+		},
+	}
+
+	table.Run(t)
+}
+
+func TestWorkloadEventsCommand(t *testing.T) {
+	defaultNamespace := "default"
+	workloadName := "my-workload"
+
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	parent := diecartov1alpha1.WorkloadBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name(workloadName)
+			d.Namespace(defaultNamespace)
+		}).
+		StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+			d.Resources(
+				diecartov1alpha1.RealizedResourceBlank.
+					Name("image-builder").
+					StampedRef(&corev1.ObjectReference{
+						APIVersion: "kpack.io/v1alpha2",
+						Kind:       "Image",
+						Name:       workloadName,
+					}).
+					DieRelease(),
+				diecartov1alpha1.RealizedResourceBlank.
+					Name("config-provider").
+					DieRelease(),
+			)
+		})
+	pod := diecorev1.PodBlank.
+		MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+			d.Name("my-workload-pod")
+			d.Namespace(defaultNamespace)
+			d.AddLabel(cartov1alpha1.WorkloadLabelName, workloadName)
+		})
+	event := func(name, apiVersion, kind, object, reason string, age time.Duration) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: defaultNamespace,
+				Name:      name,
+			},
+			InvolvedObject: corev1.ObjectReference{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Namespace:  defaultNamespace,
+				Name:       object,
+			},
+			Type:          corev1.EventTypeNormal,
+			Reason:        reason,
+			Message:       reason + " " + object,
+			LastTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		}
+	}
+	workloadEvent := event("workload-event", "carto.run/v1alpha1", "Workload", workloadName, "StampedObject", 60*time.Minute)
+	imageEvent := event("image-event", "kpack.io/v1alpha2", "Image", workloadName, "Created", 30*time.Minute)
+	podEvent := event("pod-event", "v1", "Pod", "my-workload-pod", "Scheduled", 15*time.Minute)
+	otherEvent := event("other-event", "v1", "Pod", "other-pod", "Scheduled", 15*time.Minute)
+	otherGroupEvent := event("other-group-event", "images.example.com/v1", "Image", workloadName, "Pulled", 20*time.Minute)
+
+	// cancels the context of watch test cases
+	var cancel context.CancelFunc
+
+	table := clitesting.CommandTestSuite{
+		{
+			Name:        "invalid args",
+			Args:        []string{},
+			ShouldError: true,
+		},
+		{
+			Name:        "workload not found",
+			Args:        []string{workloadName},
+			ShouldError: true,
+			ExpectOutput: `
+Workload "default/my-workload" not found
+`,
+		},
+		{
+			Name:         "get error",
+			Args:         []string{workloadName},
+			GivenObjects: []client.Object{parent},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("get", "Workload"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name:         "list pods error",
+			Args:         []string{workloadName},
+			GivenObjects: []client.Object{parent},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("list", "PodList"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name:         "list events error",
+			Args:         []string{workloadName},
+			GivenObjects: []client.Object{parent},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("list", "EventList"),
+			},
+			ShouldError: true,
+		},
+		{
+			Name:         "no events",
+			Args:         []string{workloadName},
+			GivenObjects: []client.Object{parent, pod, otherEvent},
+			ExpectOutput: `
+No events found for workload.
+`,
+		},
+		{
+			Name:         "events for workload, stamped objects and pods",
+			Args:         []string{workloadName},
+			GivenObjects: []client.Object{parent, pod, podEvent, otherEvent, otherGroupEvent, imageEvent, workloadEvent},
+			ExpectOutput: `
+LAST SEEN   TYPE     OBJECT                 REASON          MESSAGE
+60m         Normal   Workload/my-workload   StampedObject   StampedObject my-workload
+30m         Normal   Image/my-workload      Created         Created my-workload
+15m         Normal   Pod/my-workload-pod    Scheduled       Scheduled my-workload-pod
+`,
+		},
+		{
+			Name:         "recent events",
+			Args:         []string{workloadName, flags.SinceFlagName, "20m"},
+			GivenObjects: []client.Object{parent, pod, podEvent, otherEvent, imageEvent, workloadEvent},
+			ExpectOutput: `
+LAST SEEN   TYPE     OBJECT                REASON      MESSAGE
+15m         Normal   Pod/my-workload-pod   Scheduled   Scheduled my-workload-pod
+`,
+		},
+		{
+			Name:         "watch events",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent, pod, imageEvent},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				existingEvent := imageEvent.DeepCopy()
+				existingEvent.ResourceVersion = "999"
+				newEvent := podEvent.DeepCopy()
+				newEvent.ResourceVersion = "1000"
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Added, Object: existingEvent},
+					{Type: watch.Added, Object: otherEvent.DeepCopy()},
+					{Type: watch.Added, Object: newEvent},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				ctx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				cancel()
+				return nil
+			},
+			ExpectOutput: `
+LAST SEEN   TYPE     OBJECT              REASON    MESSAGE
+30m         Normal   Image/my-workload   Created   Created my-workload
+15m   Normal   Pod/my-workload-pod   Scheduled   Scheduled my-workload-pod
+`,
+		},
+		{
+			Name:         "watch restart error",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				ctx = watchhelper.WithWatcher(ctx, watchfakes.NewFakeWithRestartErr(config.Client))
+				return ctx, nil
+			},
+			ShouldError: true,
+			ExpectOutput: `
+LAST SEEN   TYPE   OBJECT   REASON   MESSAGE
+`,
+		},
+		{
+			Name:         "watch error",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				fakeWatcher := watchfakes.NewFakeWithWatch(true, config.Client, []watch.Event{})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			ShouldError: true,
+			ExpectOutput: `
+LAST SEEN   TYPE   OBJECT   REASON   MESSAGE
+`,
+		},
+	}
+
+	table.Run(t, scheme, commands.NewWorkloadEventsCommand)
+}
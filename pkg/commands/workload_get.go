@@ -17,21 +17,30 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	k8swatch "k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
 	knativeservingv1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/knative/serving/v1"
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/watch"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
@@ -41,10 +50,18 @@ type WorkloadGetOptions struct {
 	Namespace string
 	Name      string
 
-	Export bool
-	Output string
+	Export    bool
+	Output    string
+	ShowGraph bool
+	Details   bool
+	Resource  string
+	Watch     bool
 }
 
+// maxResourceEvents is the number of most recent events shown for an object stamped by a supply
+// chain resource
+const maxResourceEvents = 10
+
 var (
 	_ validation.Validatable = (*WorkloadGetOptions)(nil)
 	_ cli.Executable         = (*WorkloadGetOptions)(nil)
@@ -62,7 +79,47 @@ func (opts *WorkloadGetOptions) Validate(ctx context.Context) validation.FieldEr
 	}
 
 	if opts.Output != "" {
-		errs = errs.Also(validation.Enum(opts.Output, flags.OutputFlagName, []string{printer.OutputFormatJson, printer.OutputFormatYaml, printer.OutputFormatYml}))
+		errs = errs.Also(validation.Enum(opts.Output, flags.OutputFlagName, []string{printer.OutputFormatJson, printer.OutputFormatYaml, printer.OutputFormatYml, printer.OutputFormatDot, printer.OutputFormatMermaid}))
+	}
+
+	if opts.Export && isGraphOutputFormat(opts.Output) {
+		errs = errs.Also(validation.ErrDisallowedFields(flags.OutputFlagName, fmt.Sprintf("%q is not supported with %s", opts.Output, flags.ExportFlagName)))
+	}
+
+	if opts.ShowGraph && opts.Export {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.ShowGraphFlagName, flags.ExportFlagName))
+	}
+
+	if opts.ShowGraph && opts.Output != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.ShowGraphFlagName, flags.OutputFlagName))
+	}
+
+	if opts.Details && opts.Resource != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.DetailsFlagName, flags.ResourceFlagName))
+	}
+
+	if opts.Details && opts.Export {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.DetailsFlagName, flags.ExportFlagName))
+	}
+
+	if opts.Details && opts.Output != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.DetailsFlagName, flags.OutputFlagName))
+	}
+
+	if opts.Resource != "" && opts.Export {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.ResourceFlagName, flags.ExportFlagName))
+	}
+
+	if opts.Resource != "" && opts.Output != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.ResourceFlagName, flags.OutputFlagName))
+	}
+
+	if opts.Watch && opts.Export {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.WatchFlagName, flags.ExportFlagName))
+	}
+
+	if opts.Watch && opts.Output != "" {
+		errs = errs.Also(validation.ErrMultipleOneOf(flags.WatchFlagName, flags.OutputFlagName))
 	}
 
 	return errs
@@ -102,6 +159,13 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		return nil
 	}
 
+	switch opts.Output {
+	case printer.OutputFormatDot:
+		return printer.WorkloadResourcesDotPrinter(c.Stdout, workload)
+	case printer.OutputFormatMermaid:
+		return printer.WorkloadResourcesMermaidPrinter(c.Stdout, workload)
+	}
+
 	if opts.Output != "" {
 		export, err := printer.OutputResource(workload, printer.OutputFormat(opts.Output), c.Scheme)
 		if err != nil {
@@ -113,6 +177,17 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		return nil
 	}
 
+	if opts.Watch {
+		return opts.watchWorkload(ctx, c, workload)
+	}
+	return opts.printWorkload(ctx, c, workload)
+}
+
+func (opts *WorkloadGetOptions) printWorkload(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) error {
+	if opts.Resource != "" {
+		return opts.printWorkloadResource(ctx, c, workload)
+	}
+
 	workloadStatusReadyCond := printer.FindCondition(workload.Status.Conditions, cartov1alpha1.WorkloadConditionReady)
 	c.Printf(printer.ResourceStatus(workload.Name, workloadStatusReadyCond))
 
@@ -156,6 +231,11 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 	c.Printf("\n")
 	if len(workload.Status.Resources) == 0 {
 		c.Infof("Supply Chain resources not found.\n")
+	} else if opts.ShowGraph {
+		c.Boldf("Supply Chain Resources\n")
+		if err := printer.WorkloadResourcesGraphPrinter(c.Stdout, workload); err != nil {
+			return err
+		}
 	} else {
 		if err := printer.WorkloadResourcesPrinter(c.Stdout, workload); err != nil {
 			return err
@@ -173,6 +253,19 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 		}
 	}
 
+	if opts.Details {
+		for i := range workload.Status.Resources {
+			resource := &workload.Status.Resources[i]
+			if cond := printer.FindCondition(resource.Conditions, cartov1alpha1.ConditionResourceReady); cond != nil && cond.Status == metav1.ConditionTrue {
+				continue
+			}
+			c.Printf("\n")
+			if err := printResourceDetails(ctx, c, workload, resource); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(workload.Spec.ServiceClaims) > 0 {
 		c.Printf("\n")
 		c.Boldf("Services\n")
@@ -182,7 +275,7 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 	}
 
 	pods := &corev1.PodList{}
-	err = c.List(ctx, pods, client.InNamespace(workload.Namespace), client.MatchingLabels{cartov1alpha1.WorkloadLabelName: workload.Name})
+	err := c.List(ctx, pods, client.InNamespace(workload.Namespace), client.MatchingLabels{cartov1alpha1.WorkloadLabelName: workload.Name})
 	if err != nil {
 		c.Eprintf("\n")
 		c.Eerrorf("Failed to list pods:\n")
@@ -216,15 +309,292 @@ func (opts *WorkloadGetOptions) Exec(ctx context.Context, c *cli.Config) error {
 	return nil
 }
 
+func (opts *WorkloadGetOptions) printWorkloadResource(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) error {
+	names := []string{}
+	for i := range workload.Status.Resources {
+		resource := &workload.Status.Resources[i]
+		if resource.Name == opts.Resource {
+			return printResourceDetails(ctx, c, workload, resource)
+		}
+		names = append(names, resource.Name)
+	}
+
+	c.Errorf("Resource %q not found in workload %q\n", opts.Resource, fmt.Sprintf("%s/%s", workload.Namespace, workload.Name))
+	if len(names) != 0 {
+		c.Infof("Available resources: %s\n", strings.Join(names, ", "))
+	}
+	return cli.SilenceError(fmt.Errorf("resource %q not found", opts.Resource))
+}
+
+// printResourceDetails follows the stamped reference of the supply chain resource and prints the
+// readiness of the stamped object with its most recent events
+func printResourceDetails(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload, resource *cartov1alpha1.RealizedResource) error {
+	c.Boldf("Resource %s\n", resource.Name)
+	if resource.StampedRef == nil {
+		if err := printer.WorkloadResourceDetailsPrinter(c.Stdout, workload, resource, nil); err != nil {
+			return err
+		}
+		c.Infof("No object stamped for resource.\n")
+		return nil
+	}
+
+	namespace := resource.StampedRef.Namespace
+	if namespace == "" {
+		namespace = workload.Namespace
+	}
+	stamped := &unstructured.Unstructured{}
+	stamped.SetAPIVersion(resource.StampedRef.APIVersion)
+	stamped.SetKind(resource.StampedRef.Kind)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: resource.StampedRef.Name}, stamped); err != nil {
+		if err := printer.WorkloadResourceDetailsPrinter(c.Stdout, workload, resource, nil); err != nil {
+			return err
+		}
+		c.Eerrorf("Failed to get stamped object:\n")
+		c.Eprintf("  %s\n", err)
+		return nil
+	}
+	if err := printer.WorkloadResourceDetailsPrinter(c.Stdout, workload, resource, stamped); err != nil {
+		return err
+	}
+
+	events := &corev1.EventList{}
+	if err := c.List(ctx, events, client.InNamespace(namespace)); err != nil {
+		c.Eprintf("\n")
+		c.Eerrorf("Failed to list events:\n")
+		c.Eprintf("  %s\n", err)
+		return nil
+	}
+	events = recentEvents(events, stamped)
+	c.Printf("\n")
+	if len(events.Items) == 0 {
+		c.Infof("No events found for resource.\n")
+		return nil
+	}
+	return printer.EventTablePrinter(c.Stdout, events)
+}
+
+// recentEvents filters the events involving the object, keeping the most recent events oldest first
+func recentEvents(events *corev1.EventList, obj client.Object) *corev1.EventList {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	filtered := &corev1.EventList{}
+	for _, event := range events.Items {
+		involved := event.InvolvedObject
+		if obj.GetUID() != "" && involved.UID != "" {
+			if involved.UID != obj.GetUID() {
+				continue
+			}
+		} else if apiGroup(involved.APIVersion) != gvk.Group || involved.Kind != gvk.Kind || involved.Name != obj.GetName() {
+			continue
+		}
+		filtered.Items = append(filtered.Items, event)
+	}
+	sort.SliceStable(filtered.Items, func(i, j int) bool {
+		return printer.EventTimestamp(&filtered.Items[i]).Time.Before(printer.EventTimestamp(&filtered.Items[j]).Time)
+	})
+	if len(filtered.Items) > maxResourceEvents {
+		filtered.Items = filtered.Items[len(filtered.Items)-maxResourceEvents:]
+	}
+	return filtered
+}
+
+func isGraphOutputFormat(format string) bool {
+	return format == printer.OutputFormatDot || format == printer.OutputFormatMermaid
+}
+
+// watchWorkload renders the workload report and renders it again each time the workload, its pods or
+// its Knative services change, until the workload is deleted or the context is done
+func (opts *WorkloadGetOptions) watchWorkload(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload) error {
+	clientWithWatch, err := watch.GetWatcher(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	selector := client.MatchingLabels{cartov1alpha1.WorkloadLabelName: workload.Name}
+	watchErrs := make(chan error)
+	workloadEvents, err := watchEvents(ctx, watchErrs, clientWithWatch, &cartov1alpha1.WorkloadList{}, client.InNamespace(workload.Namespace))
+	if err != nil {
+		return err
+	}
+	podEvents, err := watchEvents(ctx, watchErrs, clientWithWatch, &corev1.PodList{}, client.InNamespace(workload.Namespace), selector)
+	if err != nil {
+		return err
+	}
+	// Knative Serving is not installed on every cluster, like listing services, watching them is best
+	// effort and failing to restart the watch does not end the watch of the workload
+	ksvcErrs := make(chan error)
+	ksvcEvents, _ := watchEvents(ctx, ksvcErrs, clientWithWatch, &knativeservingv1.ServiceList{}, client.InNamespace(workload.Namespace), selector)
+
+	if err := opts.renderWorkload(ctx, c, workload, false); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watchErrs:
+			return err
+		case err := <-ksvcErrs:
+			c.Infof("WARNING: stopped watching Knative services: %s\n", err)
+			continue
+		case event := <-workloadEvents:
+			obj, ok := event.Object.(*cartov1alpha1.Workload)
+			if !ok || obj.Name != workload.Name || obj.Namespace != workload.Namespace {
+				continue
+			}
+			if event.Type == k8swatch.Deleted {
+				c.Printf("\n")
+				c.Infof("Workload %q was deleted\n", fmt.Sprintf("%s/%s", workload.Namespace, workload.Name))
+				return nil
+			}
+			workload = obj
+		case event := <-podEvents:
+			if _, ok := event.Object.(*corev1.Pod); !ok {
+				continue
+			}
+		case event := <-ksvcEvents:
+			if _, ok := event.Object.(*knativeservingv1.Service); !ok {
+				continue
+			}
+		}
+		if err := opts.renderWorkload(ctx, c, workload, true); err != nil {
+			return err
+		}
+	}
+}
+
+// renderWorkload prints the whole workload report at once, replacing the previous report when
+// the output is a terminal
+func (opts *WorkloadGetOptions) renderWorkload(ctx context.Context, c *cli.Config, workload *cartov1alpha1.Workload, refresh bool) error {
+	buf := &bytes.Buffer{}
+	bc := *c
+	bc.Stdout = buf
+	if err := opts.printWorkload(ctx, &bc, workload); err != nil {
+		return err
+	}
+
+	if f, ok := c.Stdout.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		// move the cursor home and clear the screen
+		c.Printf("\033[H\033[2J")
+	} else if refresh {
+		c.Printf("\n")
+	}
+	_, err := buf.WriteTo(c.Stdout)
+	return err
+}
+
+// watchRestartBackoff delays restarting a watch closed by the server, the delay grows each time the
+// watch is closed again shortly after being restarted
+var watchRestartBackoff = k8swait.Backoff{
+	Duration: 100 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    8,
+	Cap:      10 * time.Second,
+}
+
+// watchRestartAttempts is the number of consecutive times restarting a watch may fail before the
+// error is reported
+const watchRestartAttempts = 3
+
+// watchEvents streams events for the list type until the context is done. The watch is restarted
+// with a backoff when the server closes it, a closed channel is never returned. When the watch can
+// not be restarted the error is sent to errs and no more events are streamed.
+func watchEvents(ctx context.Context, errs chan<- error, watcher client.WithWatch, list client.ObjectList, listOpts ...client.ListOption) (<-chan k8swatch.Event, error) {
+	w, err := watcher.Watch(ctx, list, listOpts...)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan k8swatch.Event)
+	go func() {
+		backoff := watchRestartBackoff
+		for {
+			started := time.Now()
+			if done := forwardEvents(ctx, w, events); done {
+				return
+			}
+			if time.Since(started) > backoff.Cap {
+				// the watch was healthy, restart it promptly
+				backoff = watchRestartBackoff
+			}
+			if w, err = restartWatch(ctx, &backoff, watcher, list, listOpts...); err != nil {
+				if ctx.Err() == nil {
+					select {
+					case errs <- err:
+					case <-ctx.Done():
+					}
+				}
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// restartWatch starts the watch again after the backoff delay, retrying when the watch fails to start
+func restartWatch(ctx context.Context, backoff *k8swait.Backoff, watcher client.WithWatch, list client.ObjectList, listOpts ...client.ListOption) (k8swatch.Interface, error) {
+	var err error
+	for attempt := 0; attempt < watchRestartAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff.Step()):
+		}
+		var w k8swatch.Interface
+		if w, err = watcher.Watch(ctx, list, listOpts...); err == nil {
+			return w, nil
+		}
+	}
+	return nil, err
+}
+
+// forwardEvents sends the events from the watch until the watch is closed, returning true once the
+// context is done
+func forwardEvents(ctx context.Context, w k8swatch.Interface, events chan<- k8swatch.Event) bool {
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return false
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return true
+			}
+		}
+	}
+}
+
 func NewWorkloadGetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 	opts := &WorkloadGetOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "get",
 		Short: "Get details from a workload",
-		Long:  strings.TrimSpace(`Get details from a workload`),
+		Long: strings.TrimSpace(`
+Get details from a workload.
+
+Use ` + flags.DetailsFlagName + ` to follow the objects stamped by the supply chain resources that are not
+ready and show their ready condition and recent events, or ` + flags.ResourceFlagName + ` to show only the
+stamped object of a single resource.
+
+With ` + flags.WatchFlagName + ` the details are refreshed every time the workload, its pods or its Knative
+services change, until the workload is deleted. To stop watching, press Ctl-c in the shell or kill
+the process.
+`),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s workload get my-workload", c.Name),
+			fmt.Sprintf("%s workload get my-workload %s", c.Name, flags.WatchFlagName),
+			fmt.Sprintf("%s workload get my-workload %s", c.Name, flags.ShowGraphFlagName),
+			fmt.Sprintf("%s workload get my-workload %s", c.Name, flags.DetailsFlagName),
+			fmt.Sprintf("%s workload get my-workload %s image-builder", c.Name, flags.ResourceFlagName),
+			fmt.Sprintf("%s workload get my-workload %s dot | dot -Tsvg > supply-chain.svg", c.Name, flags.OutputFlagName),
 		}, "\n"),
 		PreRunE:           cli.ValidateE(ctx, opts),
 		RunE:              cli.ExecE(ctx, c, opts),
@@ -235,9 +605,14 @@ func NewWorkloadGetCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 		cli.NameArg(&opts.Name),
 	)
 
-	cli.NamespaceFlag(ctx, cmd, c, &opts.Namespace)
+	projectNamespaceFlag(ctx, cmd, c, &opts.Namespace)
 	cmd.Flags().BoolVar(&opts.Export, cli.StripDash(flags.ExportFlagName), false, "export workload in yaml format")
-	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "output the Workload formatted. Supported formats: \"json\", \"yaml\", \"yml\"")
+	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "output the Workload formatted. Supported formats: \"json\", \"yaml\", \"yml\", or the supply chain resources graph as \"dot\", \"mermaid\"")
+	cmd.Flags().BoolVar(&opts.Details, cli.StripDash(flags.DetailsFlagName), false, "show the stamped object, its ready condition and recent events for each supply chain resource that is not ready")
+	cmd.Flags().StringVar(&opts.Resource, cli.StripDash(flags.ResourceFlagName), "", "show the stamped object, its ready condition and recent events for the supply chain resource `name`")
+	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.ResourceFlagName), completion.SuggestWorkloadResourceNames(ctx, c))
+	cmd.Flags().BoolVar(&opts.ShowGraph, cli.StripDash(flags.ShowGraphFlagName), false, "show the supply chain resources as a graph of which resource feeds which, with their outputs")
+	cmd.Flags().BoolVarP(&opts.Watch, cli.StripDash(flags.WatchFlagName), "w", false, "watch the workload, its pods and Knative services and refresh the details when any of them changes")
 
 	return cmd
 }
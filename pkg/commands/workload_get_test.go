@@ -17,6 +17,7 @@ limitations under the License.
 package commands_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -25,7 +26,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
@@ -35,6 +39,8 @@ import (
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
 	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
+	watchhelper "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/watch"
+	watchfakes "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/watch/fake"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/commands"
 	diecartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/dies/cartographer/v1alpha1"
 	diev1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/dies/knative/serving/v1"
@@ -100,7 +106,140 @@ func TestWorkloadGetOptionsValidate(t *testing.T) {
 				Name:      "my-workload",
 				Output:    "myFormat",
 			},
-			ExpectFieldErrors: validation.EnumInvalidValue("myFormat", flags.OutputFlagName, []string{"json", "yaml", "yml"}),
+			ExpectFieldErrors: validation.EnumInvalidValue("myFormat", flags.OutputFlagName, []string{"json", "yaml", "yml", "dot", "mermaid"}),
+		},
+		{
+			Name: "watch",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Watch:     true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "watch with export",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Watch:     true,
+				Export:    true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.WatchFlagName, flags.ExportFlagName),
+		},
+		{
+			Name: "watch with output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Watch:     true,
+				Output:    "yaml",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.WatchFlagName, flags.OutputFlagName),
+		},
+		{
+			Name: "graph output format",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "mermaid",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "export with graph output format",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Export:    true,
+				Output:    "dot",
+			},
+			ExpectFieldErrors: validation.ErrDisallowedFields(flags.OutputFlagName, `"dot" is not supported with --export`),
+		},
+		{
+			Name: "show graph",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				ShowGraph: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "show graph with output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				ShowGraph: true,
+				Output:    "json",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.ShowGraphFlagName, flags.OutputFlagName),
+		},
+		{
+			Name: "show graph with export",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				ShowGraph: true,
+				Export:    true,
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.ShowGraphFlagName, flags.ExportFlagName),
+		},
+		{
+			Name: "details",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Details:   true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "resource",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Resource:  "image-builder",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "details with resource",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Details:   true,
+				Resource:  "image-builder",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.DetailsFlagName, flags.ResourceFlagName),
+		},
+		{
+			Name: "details with export and output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Details:   true,
+				Export:    true,
+				Output:    "yaml",
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrMultipleOneOf(flags.DetailsFlagName, flags.ExportFlagName),
+				validation.ErrMultipleOneOf(flags.DetailsFlagName, flags.OutputFlagName),
+			),
+		},
+		{
+			Name: "resource with export and output",
+			Validatable: &commands.WorkloadGetOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Resource:  "image-builder",
+				Export:    true,
+				Output:    "yaml",
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrMultipleOneOf(flags.ResourceFlagName, flags.ExportFlagName),
+				validation.ErrMultipleOneOf(flags.ResourceFlagName, flags.OutputFlagName),
+			),
 		},
 	}
 
@@ -166,6 +305,109 @@ func TestWorkloadGetCommand(t *testing.T) {
 			)
 		})
 
+	supplyChainParent := parent.
+		StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+			d.Resources(
+				diecartov1alpha1.RealizedResourceBlank.
+					Name("source-provider").
+					StampedRef(&corev1.ObjectReference{
+						APIVersion: "source.toolkit.fluxcd.io/v1beta1",
+						Kind:       "GitRepository",
+						Name:       workloadName,
+					}).
+					ConditionsDie(
+						diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+							Status(metav1.ConditionTrue),
+					).DieRelease(),
+				diecartov1alpha1.RealizedResourceBlank.
+					Name("image-builder").
+					TemplateRef(&corev1.ObjectReference{
+						APIVersion: "carto.run/v1alpha1",
+						Kind:       "ClusterImageTemplate",
+						Name:       "kpack-template",
+					}).
+					StampedRef(&corev1.ObjectReference{
+						APIVersion: "kpack.io/v1alpha2",
+						Kind:       "Image",
+						Namespace:  defaultNamespace,
+						Name:       workloadName,
+					}).
+					ConditionsDie(
+						diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+							Status(metav1.ConditionFalse),
+					).DieRelease(),
+				diecartov1alpha1.RealizedResourceBlank.
+					Name("config-provider").
+					ConditionsDie(
+						diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+							Status(metav1.ConditionUnknown),
+					).DieRelease(),
+			)
+		})
+	kpackImage := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "kpack.io/v1alpha2",
+			"kind":       "Image",
+			"metadata": map[string]interface{}{
+				"namespace": defaultNamespace,
+				"name":      workloadName,
+				"uid":       "kpack-image-uid",
+				// the fake client sets the resource version of given objects
+				"resourceVersion": "999",
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":   "BuilderReady",
+						"status": "True",
+					},
+					map[string]interface{}{
+						"type":    "Ready",
+						"status":  "False",
+						"reason":  "BuildFailed",
+						"message": "build my-workload-build-1 failed",
+					},
+				},
+			},
+		},
+	}
+	kpackImageEvent := func(name, reason string, age time.Duration) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: defaultNamespace,
+				Name:      name,
+			},
+			InvolvedObject: corev1.ObjectReference{
+				APIVersion: "kpack.io/v1alpha2",
+				Kind:       "Image",
+				Namespace:  defaultNamespace,
+				Name:       workloadName,
+				UID:        types.UID("kpack-image-uid"),
+			},
+			Type:          corev1.EventTypeWarning,
+			Reason:        reason,
+			Message:       "build my-workload-build-1 failed",
+			LastTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		}
+	}
+	otherEvent := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: defaultNamespace,
+			Name:      "other-event",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: "Pod",
+			Name: "other-pod",
+			UID:  types.UID("other-pod-uid"),
+		},
+		Type:          corev1.EventTypeNormal,
+		Reason:        "Scheduled",
+		LastTimestamp: metav1.NewTime(time.Now()),
+	}
+
+	// cancels the context of watch test cases
+	var cancel context.CancelFunc
+
 	table := clitesting.CommandTestSuite{
 		{
 			Name:        "invalid args",
@@ -625,6 +867,284 @@ Issues
 reason:    OopsieDoodle
 message:   a hopefully informative message about what went wrong
 
+No pods found for workload.
+`,
+		}, {
+			Name: "show resources as graph",
+			Args: []string{workloadName, flags.ShowGraphFlagName},
+			GivenObjects: []client.Object{
+				parent.
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.Resources(
+							diecartov1alpha1.RealizedResourceBlank.
+								Name("source-provider").
+								Outputs(cartov1alpha1.Output{
+									Name:    "url",
+									Preview: "http://source.example.com/my-workload.tar.gz",
+									Digest:  "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+								}).
+								ConditionsDie(
+									diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+										Status(metav1.ConditionTrue),
+								).DieRelease(),
+							diecartov1alpha1.RealizedResourceBlank.
+								Name("image-builder").
+								Inputs(cartov1alpha1.Input{Name: "source-provider"}).
+								ConditionsDie(
+									diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+										Status(metav1.ConditionFalse),
+								).DieRelease(),
+						)
+					}),
+			},
+			ExpectOutput: `
+---
+# my-workload: <unknown>
+---
+Supply Chain reference not found.
+
+Supply Chain Resources
+source-provider (True, <unknown>)
+│ url: http://source.example.com/my-workload.tar.gz (sha256:0123456789ab)
+└── image-builder (False, <unknown>)
+
+Issues
+No issues reported.
+
+No pods found for workload.
+`,
+		}, {
+			Name: "output resources graph in dot format",
+			Args: []string{workloadName, flags.OutputFlagName, "dot"},
+			GivenObjects: []client.Object{
+				parent.
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.Resources(
+							diecartov1alpha1.RealizedResourceBlank.
+								Name("source-provider").
+								Outputs(cartov1alpha1.Output{
+									Name:    "url",
+									Preview: "http://source.example.com/my-workload.tar.gz",
+									Digest:  "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+								}).
+								ConditionsDie(
+									diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+										Status(metav1.ConditionTrue),
+								).DieRelease(),
+							diecartov1alpha1.RealizedResourceBlank.
+								Name("image-builder").
+								Inputs(cartov1alpha1.Input{Name: "source-provider"}).
+								ConditionsDie(
+									diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+										Status(metav1.ConditionFalse),
+								).DieRelease(),
+						)
+					}),
+			},
+			ExpectOutput: `
+digraph "my-workload" {
+  rankdir=LR;
+  node [shape=box];
+  "source-provider" [label="source-provider (True, <unknown>)\nurl: http://source.example.com/my-workload.tar.gz (sha256:0123456789ab)", color=green];
+  "image-builder" [label="image-builder (False, <unknown>)", color=red];
+  "source-provider" -> "image-builder";
+}
+`,
+		}, {
+			Name: "output resources graph in mermaid format",
+			Args: []string{workloadName, flags.OutputFlagName, "mermaid"},
+			GivenObjects: []client.Object{
+				parent.
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.Resources(
+							diecartov1alpha1.RealizedResourceBlank.
+								Name("source-provider").
+								Outputs(cartov1alpha1.Output{
+									Name:    "url",
+									Preview: "http://source.example.com/my-workload.tar.gz",
+									Digest:  "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+								}).
+								ConditionsDie(
+									diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+										Status(metav1.ConditionTrue),
+								).DieRelease(),
+							diecartov1alpha1.RealizedResourceBlank.
+								Name("image-builder").
+								Inputs(cartov1alpha1.Input{Name: "source-provider"}).
+								ConditionsDie(
+									diecartov1alpha1.WorkloadConditionResourceReadyBlank.
+										Status(metav1.ConditionFalse),
+								).DieRelease(),
+						)
+					}),
+			},
+			ExpectOutput: `
+flowchart LR
+  r0["source-provider (True, #lt;unknown#gt;)<br/>url: http://source.example.com/my-workload.tar.gz (sha256:0123456789ab)"]:::ready
+  r1["image-builder (False, #lt;unknown#gt;)"]:::failed
+  r0 --> r1
+  classDef ready stroke:green
+  classDef failed stroke:red
+  classDef unknown stroke:orange
+`,
+		}, {
+			Name: "show resource details",
+			Args: []string{workloadName, flags.ResourceFlagName, "image-builder"},
+			GivenObjects: []client.Object{
+				supplyChainParent,
+				kpackImage,
+				kpackImageEvent("second", "BuildFailed", 15*time.Minute),
+				kpackImageEvent("first", "BuildStarted", 30*time.Minute),
+				otherEvent,
+			},
+			ExpectOutput: `
+Resource image-builder
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+ready:      False
+reason:     BuildFailed
+message:    build my-workload-build-1 failed
+
+LAST SEEN   TYPE      REASON         MESSAGE
+30m         Warning   BuildStarted   build my-workload-build-1 failed
+15m         Warning   BuildFailed    build my-workload-build-1 failed
+`,
+		}, {
+			Name: "show resource details with events without uid",
+			Args: []string{workloadName, flags.ResourceFlagName, "image-builder"},
+			GivenObjects: []client.Object{
+				supplyChainParent,
+				kpackImage,
+				func() *corev1.Event {
+					event := kpackImageEvent("first", "BuildStarted", 30*time.Minute)
+					event.InvolvedObject.UID = ""
+					return event
+				}(),
+				func() *corev1.Event {
+					event := kpackImageEvent("other-group", "Pulled", 15*time.Minute)
+					event.InvolvedObject.APIVersion = "images.example.com/v1"
+					event.InvolvedObject.UID = ""
+					return event
+				}(),
+			},
+			ExpectOutput: `
+Resource image-builder
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+ready:      False
+reason:     BuildFailed
+message:    build my-workload-build-1 failed
+
+LAST SEEN   TYPE      REASON         MESSAGE
+30m         Warning   BuildStarted   build my-workload-build-1 failed
+`,
+		}, {
+			Name: "show resource details without events",
+			Args: []string{workloadName, flags.ResourceFlagName, "image-builder"},
+			GivenObjects: []client.Object{
+				supplyChainParent,
+				kpackImage,
+				otherEvent,
+			},
+			ExpectOutput: `
+Resource image-builder
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+ready:      False
+reason:     BuildFailed
+message:    build my-workload-build-1 failed
+
+No events found for resource.
+`,
+		}, {
+			Name: "show resource details with missing stamped object",
+			Args: []string{workloadName, flags.ResourceFlagName, "source-provider"},
+			GivenObjects: []client.Object{
+				supplyChainParent,
+			},
+			ExpectOutput: `
+Resource source-provider
+object:   GitRepository.source.toolkit.fluxcd.io/my-workload
+Failed to get stamped object:
+  gitrepositories.source.toolkit.fluxcd.io "my-workload" not found
+`,
+		}, {
+			Name: "show resource details without stamped object",
+			Args: []string{workloadName, flags.ResourceFlagName, "config-provider"},
+			GivenObjects: []client.Object{
+				supplyChainParent,
+			},
+			ExpectOutput: `
+Resource config-provider
+No object stamped for resource.
+`,
+		}, {
+			Name: "show resource details error listing events",
+			Args: []string{workloadName, flags.ResourceFlagName, "image-builder"},
+			GivenObjects: []client.Object{
+				supplyChainParent,
+				kpackImage,
+			},
+			WithReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("list", "EventList"),
+			},
+			ExpectOutput: `
+Resource image-builder
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+ready:      False
+reason:     BuildFailed
+message:    build my-workload-build-1 failed
+
+Failed to list events:
+  inducing failure for list EventList
+`,
+		}, {
+			Name: "resource not found",
+			Args: []string{workloadName, flags.ResourceFlagName, "image-provider"},
+			GivenObjects: []client.Object{
+				supplyChainParent,
+			},
+			ShouldError: true,
+			ExpectOutput: `
+Resource "image-provider" not found in workload "default/my-workload"
+Available resources: source-provider, image-builder, config-provider
+`,
+		}, {
+			Name: "show details for resources that are not ready",
+			Args: []string{workloadName, flags.DetailsFlagName},
+			GivenObjects: []client.Object{
+				supplyChainParent,
+				kpackImage,
+				kpackImageEvent("second", "BuildFailed", 15*time.Minute),
+			},
+			ExpectOutput: `
+---
+# my-workload: <unknown>
+---
+Supply Chain reference not found.
+
+RESOURCE          READY     TIME
+source-provider   True      <unknown>
+image-builder     False     <unknown>
+config-provider   Unknown   <unknown>
+
+Issues
+No issues reported.
+
+Resource image-builder
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+ready:      False
+reason:     BuildFailed
+message:    build my-workload-build-1 failed
+
+LAST SEEN   TYPE      REASON        MESSAGE
+15m         Warning   BuildFailed   build my-workload-build-1 failed
+
+Resource config-provider
+No object stamped for resource.
+
 No pods found for workload.
 `,
 		}, {
@@ -888,6 +1408,28 @@ Issues
 No issues reported.
 
 No pods found for workload.
+`,
+		}, {
+			Name: "namespace from the project config",
+			Args: []string{workloadName, flags.ExportFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/project.yaml"
+				return ctx, nil
+			},
+			GivenObjects: []client.Object{
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Namespace("my-namespace")
+					}),
+			},
+			ExpectOutput: `
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: my-workload
+  namespace: my-namespace
+spec: {}
 `,
 		}, {
 			Name: "get workload exported data",
@@ -1031,6 +1573,187 @@ status:
 	}
 }
 `,
+		}, {
+			Name:         "watch workload",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				workload := parent.
+					StatusDie(func(d *diecartov1alpha1.WorkloadStatusDie) {
+						d.ConditionsDie(
+							diecartov1alpha1.WorkloadConditionReadyBlank.Status(metav1.ConditionTrue),
+						)
+					})
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Modified, Object: workload.DieReleasePtr()},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				ctx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				cancel()
+				return nil
+			},
+			ExpectOutput: `
+---
+# my-workload: <unknown>
+---
+Supply Chain reference not found.
+
+Supply Chain resources not found.
+
+Issues
+No issues reported.
+
+No pods found for workload.
+
+---
+# my-workload: Ready
+---
+Supply Chain
+name:          <none>
+last update:   <unknown>
+ready:         True
+
+Supply Chain resources not found.
+
+Issues
+No issues reported.
+
+No pods found for workload.
+`,
+		}, {
+			Name:         "watch workload pods",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent, pod1Die},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Added, Object: pod1Die.DieReleasePtr()},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				ctx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				cancel()
+				return nil
+			},
+			ExpectOutput: `
+---
+# my-workload: <unknown>
+---
+Supply Chain reference not found.
+
+Supply Chain resources not found.
+
+Issues
+No issues reported.
+
+Pods
+NAME   STATUS   RESTARTS   AGE
+pod1            0          <unknown>
+
+---
+# my-workload: <unknown>
+---
+Supply Chain reference not found.
+
+Supply Chain resources not found.
+
+Issues
+No issues reported.
+
+Pods
+NAME   STATUS   RESTARTS   AGE
+pod1            0          <unknown>
+`,
+		}, {
+			Name:         "watch deleted workload",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				fakeWatcher := watchfakes.NewFakeWithWatch(false, config.Client, []watch.Event{
+					{Type: watch.Deleted, Object: parent.DieReleasePtr()},
+				})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			ExpectOutput: `
+---
+# my-workload: <unknown>
+---
+Supply Chain reference not found.
+
+Supply Chain resources not found.
+
+Issues
+No issues reported.
+
+No pods found for workload.
+
+Workload "default/my-workload" was deleted
+`,
+		}, {
+			Name:         "watch restart error",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				ctx = watchhelper.WithWatcher(ctx, watchfakes.NewFakeWithRestartErr(config.Client))
+				return ctx, nil
+			},
+			ShouldError: true,
+			ExpectOutput: `
+---
+# my-workload: <unknown>
+---
+Supply Chain reference not found.
+
+Supply Chain resources not found.
+
+Issues
+No issues reported.
+
+No pods found for workload.
+`,
+		}, {
+			Name:         "watch knative services restart error",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				ctx = watchhelper.WithWatcher(ctx, watchfakes.NewFakeWithRestartErr(config.Client, &knativeservingv1.ServiceList{}))
+				// outlasts the restart attempts of the knative services watch
+				ctx, cancel = context.WithTimeout(ctx, 2*time.Second)
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				cancel()
+				return nil
+			},
+			ExpectOutput: `
+---
+# my-workload: <unknown>
+---
+Supply Chain reference not found.
+
+Supply Chain resources not found.
+
+Issues
+No issues reported.
+
+No pods found for workload.
+WARNING: stopped watching Knative services: failed to restart watcher
+`,
+		}, {
+			Name:         "watch error",
+			Args:         []string{workloadName, flags.WatchFlagName},
+			GivenObjects: []client.Object{parent},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				fakeWatcher := watchfakes.NewFakeWithWatch(true, config.Client, []watch.Event{})
+				ctx = watchhelper.WithWatcher(ctx, fakeWatcher)
+				return ctx, nil
+			},
+			ShouldError: true,
 		},
 	}
 
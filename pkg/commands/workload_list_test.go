@@ -17,6 +17,7 @@ limitations under the License.
 package commands_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
 	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/commands"
@@ -132,6 +134,46 @@ No workloads found.
 			ExpectOutput: `
 NAME            APP       READY       AGE
 test-workload   <empty>   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "lists items in the project config namespace",
+			Args: []string{},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/project.yaml"
+				return ctx, nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name(workloadOtherName)
+						d.Namespace("my-namespace")
+					}),
+			},
+			ExpectOutput: `
+NAME                  APP       READY       AGE
+test-other-workload   <empty>   <unknown>   <unknown>
+`,
+		},
+		{
+			Name: "namespace flag takes precedence over the project config",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/project.yaml"
+				return ctx, nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+				parent.
+					MetadataDie(func(d *diemetav1.ObjectMetaDie) {
+						d.Name(workloadOtherName)
+						d.Namespace("my-namespace")
+					}),
+			},
+			ExpectOutput: `
+NAME            APP       READY       AGE
+test-workload   <empty>   <unknown>   <unknown>
 `,
 		},
 		{
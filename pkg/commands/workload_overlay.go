@@ -0,0 +1,175 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+)
+
+// overlayMergedLists are the lists of a workload a strategic merge patch merges by name, instead of
+// replacing them. The workload type does not declare patch strategies.
+var overlayMergedLists = map[string]bool{
+	".spec.env":           true,
+	".spec.build.env":     true,
+	".spec.params":        true,
+	".spec.serviceClaims": true,
+}
+
+// overlayPatchMeta tracks the path of the fields looked up in the workload schema to merge the
+// overlayMergedLists by name
+type overlayPatchMeta struct {
+	strategicpatch.LookupPatchMeta
+	path string
+}
+
+func (m overlayPatchMeta) LookupPatchMetadataForStruct(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	schema, meta, err := m.LookupPatchMeta.LookupPatchMetadataForStruct(key)
+	return overlayPatchMeta{LookupPatchMeta: schema, path: m.path + "." + key}, meta, err
+}
+
+func (m overlayPatchMeta) LookupPatchMetadataForSlice(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	path := m.path + "." + key
+	schema, meta, err := m.LookupPatchMeta.LookupPatchMetadataForSlice(key)
+	if err == nil && overlayMergedLists[path] {
+		meta.SetPatchStrategies([]string{"merge"})
+		meta.SetPatchMergeKey("name")
+	}
+	return overlayPatchMeta{LookupPatchMeta: schema, path: path}, meta, err
+}
+
+// workloadOverlay is a patch from an overlay file. A strategic merge patch naming a workload in its
+// metadata only applies to that workload, other patches apply to every workload.
+type workloadOverlay struct {
+	// name of the workload the patch targets, empty for all workloads
+	name       string
+	jsonPatch  jsonpatch.Patch
+	mergePatch []byte
+}
+
+// loadOverlays reads the patches of an overlay file. Each yaml or json document is either a list of
+// JSON patch operations or a strategic merge patch.
+func loadOverlays(path string) ([]workloadOverlay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open overlay %q: %w", path, err)
+	}
+	defer f.Close()
+
+	overlays := []workloadOverlay{}
+	d := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		var doc interface{}
+		if err := d.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("unable to load overlay %q: %w", path, err)
+		}
+		switch patch := doc.(type) {
+		case nil:
+			continue
+		case []interface{}:
+			raw, err := json.Marshal(patch)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load overlay %q: %w", path, err)
+			}
+			ops, err := jsonpatch.DecodePatch(raw)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load overlay %q: %w", path, err)
+			}
+			overlays = append(overlays, workloadOverlay{jsonPatch: ops})
+		case map[string]interface{}:
+			// the type of the patch is implied, it is never changed
+			delete(patch, "apiVersion")
+			delete(patch, "kind")
+			overlay := workloadOverlay{}
+			if metadata, ok := patch["metadata"].(map[string]interface{}); ok {
+				overlay.name, _ = metadata["name"].(string)
+			}
+			if overlay.mergePatch, err = json.Marshal(patch); err != nil {
+				return nil, fmt.Errorf("unable to load overlay %q: %w", path, err)
+			}
+			overlays = append(overlays, overlay)
+		default:
+			return nil, fmt.Errorf("unable to load overlay %q: expected a strategic merge patch or a list of JSON patch operations", path)
+		}
+	}
+	return overlays, nil
+}
+
+// applyOverlays patches the workloads with each overlay file, in order
+func (opts *WorkloadOptions) applyOverlays(workloads []*cartov1alpha1.Workload) error {
+	for _, path := range opts.Overlays {
+		overlays, err := loadOverlays(path)
+		if err != nil {
+			return err
+		}
+		for _, overlay := range overlays {
+			matched := false
+			for _, workload := range workloads {
+				if overlay.name != "" && overlay.name != workload.Name {
+					continue
+				}
+				matched = true
+				if err := overlay.apply(workload); err != nil {
+					return fmt.Errorf("unable to apply overlay %q to workload %q: %w", path, workload.Name, err)
+				}
+			}
+			if !matched {
+				return fmt.Errorf("unable to apply overlay %q: no workload named %q", path, overlay.name)
+			}
+		}
+	}
+	return nil
+}
+
+func (o workloadOverlay) apply(workload *cartov1alpha1.Workload) error {
+	original, err := json.Marshal(workload)
+	if err != nil {
+		return err
+	}
+	var patched []byte
+	if o.jsonPatch != nil {
+		patched, err = o.jsonPatch.Apply(original)
+	} else {
+		var schema strategicpatch.LookupPatchMeta
+		if schema, err = strategicpatch.NewPatchMetaFromStruct(&cartov1alpha1.Workload{}); err != nil {
+			return err
+		}
+		patched, err = strategicpatch.StrategicMergePatchUsingLookupPatchMeta(original, o.mergePatch, overlayPatchMeta{LookupPatchMeta: schema})
+	}
+	if err != nil {
+		return err
+	}
+	result := &cartov1alpha1.Workload{}
+	if err := json.Unmarshal(patched, result); err != nil {
+		return err
+	}
+	// the patch may not change the type of the workload
+	result.TypeMeta = workload.TypeMeta
+	*workload = *result
+	return nil
+}
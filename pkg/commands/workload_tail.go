@@ -34,15 +34,22 @@ import (
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
 )
 
 type WorkloadTailOptions struct {
 	Namespace string
 	Name      string
 
-	Component  string
-	Since      time.Duration
-	Timestamps bool
+	Component      string
+	Containers     []string
+	Include        []string
+	Exclude        []string
+	Since          time.Duration
+	TailLines      int64
+	MaxLogRequests int
+	Timestamps     bool
+	Output         string
 }
 
 var (
@@ -67,7 +74,22 @@ func (opts *WorkloadTailOptions) Validate(ctx context.Context) validation.FieldE
 		errs = errs.Also(validation.ErrInvalidValue(opts.Since, flags.SinceFlagName))
 	}
 
+	if opts.Output != "" {
+		errs = errs.Also(validation.Enum(opts.Output, flags.OutputFlagName, []string{printer.OutputFormatJson}))
+	}
+
+	if opts.TailLines < -1 {
+		errs = errs.Also(validation.ErrInvalidValue(opts.TailLines, flags.TailLinesFlagName))
+	}
+
+	if opts.MaxLogRequests < 0 {
+		errs = errs.Also(validation.ErrInvalidValue(opts.MaxLogRequests, flags.MaxLogRequestsFlagName))
+	}
+
 	errs = errs.Also(validation.K8sLabelValue(opts.Component, flags.ComponentFlagName))
+	errs = errs.Also(validation.K8sNames(opts.Containers, flags.ContainerFlagName))
+	errs = errs.Also(validation.Regexps(opts.Include, flags.IncludeFlagName))
+	errs = errs.Also(validation.Regexps(opts.Exclude, flags.ExcludeFlagName))
 	return errs
 }
 
@@ -90,8 +112,15 @@ func (opts *WorkloadTailOptions) Exec(ctx context.Context, c *cli.Config) error
 	if err != nil {
 		panic(err)
 	}
-	containers := []string{}
-	return logs.Tail(ctx, c, opts.Namespace, selector, containers, opts.Since, opts.Timestamps)
+	tailOpts := logs.TailOptions{
+		Include:        opts.Include,
+		Exclude:        opts.Exclude,
+		MaxLogRequests: opts.MaxLogRequests,
+	}
+	if opts.TailLines >= 0 {
+		tailOpts.TailLines = &opts.TailLines
+	}
+	return logs.Tail(ctx, c, opts.Namespace, selector, opts.Containers, opts.Since, opts.Timestamps, opts.Output, tailOpts)
 }
 
 func NewWorkloadTailCommand(ctx context.Context, c *cli.Config) *cobra.Command {
@@ -104,10 +133,19 @@ func NewWorkloadTailCommand(ctx context.Context, c *cli.Config) *cobra.Command {
 Stream logs for a workload until canceled. To cancel, press Ctl-c in
 the shell or kill the process. As new workload pods are started, the logs
 are displayed. To show historical logs use ` + flags.SinceFlagName + `.
+
+Use ` + flags.ContainerFlagName + ` to only show the logs of some containers of the pods, and
+` + flags.IncludeFlagName + ` or ` + flags.ExcludeFlagName + ` to filter log lines with regular expressions. A line is
+shown when it matches any ` + flags.IncludeFlagName + ` expression and no ` + flags.ExcludeFlagName + ` expression.
+
+With ` + flags.OutputFlagName + ` json each log line is printed as a JSON object with the pod, container,
+component, namespace, timestamp and message of the line.
 `),
 		Example: strings.Join([]string{
 			fmt.Sprintf("%s workload tail my-workload", c.Name),
 			fmt.Sprintf("%s workload tail my-workload %s 1h", c.Name, flags.SinceFlagName),
+			fmt.Sprintf("%s workload tail my-workload %s json | jq -r .message", c.Name, flags.OutputFlagName),
+			fmt.Sprintf("%s workload tail my-workload %s build %s detect %s ERROR", c.Name, flags.ComponentFlagName, flags.ContainerFlagName, flags.IncludeFlagName),
 		}, "\n"),
 		PreRunE:           cli.ValidateE(ctx, opts),
 		RunE:              cli.ExecE(ctx, c, opts),
@@ -118,11 +156,17 @@ are displayed. To show historical logs use ` + flags.SinceFlagName + `.
 		cli.NameArg(&opts.Name),
 	)
 
-	cli.NamespaceFlag(ctx, cmd, c, &opts.Namespace)
+	projectNamespaceFlag(ctx, cmd, c, &opts.Namespace)
 	cmd.Flags().StringVar(&opts.Component, cli.StripDash(flags.ComponentFlagName), "", "workload component `name` (e.g. build)")
 	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.ComponentFlagName), completion.SuggestComponentNames(ctx, c))
+	cmd.Flags().StringSliceVar(&opts.Containers, cli.StripDash(flags.ContainerFlagName), []string{}, "container `name` to show logs for (flag can be used multiple times)")
+	cmd.Flags().StringArrayVar(&opts.Include, cli.StripDash(flags.IncludeFlagName), []string{}, "only show log lines matching the `regex` (flag can be used multiple times)")
+	cmd.Flags().StringArrayVar(&opts.Exclude, cli.StripDash(flags.ExcludeFlagName), []string{}, "hide log lines matching the `regex` (flag can be used multiple times)")
 	cmd.Flags().BoolVarP(&opts.Timestamps, cli.StripDash(flags.TimestampFlagName), "t", false, "print timestamp for each log line")
 	cmd.Flags().DurationVar(&opts.Since, cli.StripDash(flags.SinceFlagName), time.Second, "time `duration` to start reading logs from")
 	cmd.RegisterFlagCompletionFunc(cli.StripDash(flags.SinceFlagName), completion.SuggestDurationUnits(ctx, completion.CommonDurationUnits))
+	cmd.Flags().Int64Var(&opts.TailLines, cli.StripDash(flags.TailLinesFlagName), -1, "`number` of recent log lines to show for each container, -1 shows all lines since "+flags.SinceFlagName)
+	cmd.Flags().IntVar(&opts.MaxLogRequests, cli.StripDash(flags.MaxLogRequestsFlagName), 0, "maximum `number` of containers to stream logs from at the same time, 0 for no limit")
+	cmd.Flags().StringVarP(&opts.Output, cli.StripDash(flags.OutputFlagName), "o", "", "output the logs formatted. Supported formats: \"json\"")
 	return cmd
 }
@@ -102,6 +102,70 @@ func TestWorkloadTailOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidValue("---", flags.ComponentFlagName),
 		},
+		{
+			Name: "filters",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace:      "default",
+				Name:           "my-workload",
+				Containers:     []string{"detect"},
+				Include:        []string{"ERROR|WARN"},
+				Exclude:        []string{"^DEBUG"},
+				TailLines:      -1,
+				MaxLogRequests: 50,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid filters",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace:  "default",
+				Name:       "my-workload",
+				Containers: []string{"Detect"},
+				Include:    []string{"[a-"},
+				Exclude:    []string{"ok", "("},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidValue("Detect", validation.CurrentField).ViaFieldIndex(flags.ContainerFlagName, 0),
+				validation.ErrInvalidValue("[a-", validation.CurrentField).ViaFieldIndex(flags.IncludeFlagName, 0),
+				validation.ErrInvalidValue("(", validation.CurrentField).ViaFieldIndex(flags.ExcludeFlagName, 1),
+			),
+		},
+		{
+			Name: "invalid tail lines",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				TailLines: -2,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue(int64(-2), flags.TailLinesFlagName),
+		},
+		{
+			Name: "invalid max log requests",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace:      "default",
+				Name:           "my-workload",
+				MaxLogRequests: -1,
+			},
+			ExpectFieldErrors: validation.ErrInvalidValue(-1, flags.MaxLogRequestsFlagName),
+		},
+		{
+			Name: "output json",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "json",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid output",
+			Validatable: &commands.WorkloadTailOptions{
+				Namespace: "default",
+				Name:      "my-workload",
+				Output:    "yaml",
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("yaml", flags.OutputFlagName, []string{"json"}),
+		},
 	}
 	table.Run(t)
 }
@@ -159,7 +223,7 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false, "", logs.TailOptions{Include: []string{}, Exclude: []string{}}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
 				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
@@ -184,7 +248,7 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s,%s=%s", cartov1alpha1.WorkloadLabelName, workloadName, apis.ComponentLabelName, "build"))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false, "", logs.TailOptions{Include: []string{}, Exclude: []string{}}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
 				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
@@ -209,7 +273,7 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false).Return(fmt.Errorf("tail error")).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false, "", logs.TailOptions{Include: []string{}, Exclude: []string{}}).Return(fmt.Errorf("tail error")).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				return ctx, nil
 			},
@@ -232,7 +296,58 @@ Workload "default/test-workload" not found
 			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, true).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, true, "", logs.TailOptions{Include: []string{}, Exclude: []string{}}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+				// simulate a user exit after 10ms
+				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+				_ = cancel
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+...tail output...
+`,
+		},
+		{
+			Name: "show logs for workload as json",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.SinceFlagName, "1h", flags.OutputFlagName, "json", workloadName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Hour, false, "json", logs.TailOptions{Include: []string{}, Exclude: []string{}}).Return(nil).Once()
+				ctx = logs.StashTailer(ctx, tailer)
+				// simulate a user exit after 10ms
+				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+				_ = cancel
+				return ctx, nil
+			},
+			CleanUp: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) error {
+				tailer := logs.RetrieveTailer(ctx).(*logs.FakeTailer)
+				tailer.AssertExpectations(t)
+				return nil
+			},
+			GivenObjects: []client.Object{
+				parent,
+			},
+			ExpectOutput: `
+...tail output...
+`,
+		},
+		{
+			Name: "show logs for workload with filters",
+			Args: []string{flags.NamespaceFlagName, defaultNamespace, flags.SinceFlagName, "1h", flags.ContainerFlagName, "detect", flags.IncludeFlagName, "ERROR", flags.IncludeFlagName, "WARN", flags.ExcludeFlagName, "retry", flags.TailLinesFlagName, "10", flags.MaxLogRequestsFlagName, "5", workloadName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				tailer := &logs.FakeTailer{}
+				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
+				tailLines := int64(10)
+				tailer.On("Tail", mock.Anything, "default", selector, []string{"detect"}, time.Hour, false, "", logs.TailOptions{Include: []string{"ERROR", "WARN"}, Exclude: []string{"retry"}, MaxLogRequests: 5, TailLines: &tailLines}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 				// simulate a user exit after 10ms
 				ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
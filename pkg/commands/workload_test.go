@@ -19,9 +19,18 @@ package commands_test
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/url"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -43,6 +52,7 @@ import (
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
 	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
 	cli "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/parsers"
 	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/validation"
 	"github.com/vmware-tanzu/apps-cli-plugin/pkg/commands"
@@ -157,6 +167,138 @@ func TestWorkloadOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidArrayValue("FOO", flags.BuildEnvFlagName, 0),
 		},
+		{
+			Name: "env files",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:    "default",
+				Name:         "my-resource",
+				EnvFile:      "testdata/app.env",
+				BuildEnvFile: "testdata/app.env",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid env file",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				EnvFile:   "testdata/invalid.env",
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidValueWithDetail("line 2", flags.EnvFileFlagName, "unterminated double quoted value"),
+				validation.ErrInvalidValueWithDetail("line 3", flags.EnvFileFlagName, "expected KEY=value or KEY-"),
+			),
+		},
+		{
+			Name: "template variables",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				FilePath:  "testdata/workload-template.yaml",
+				Vars:      []string{"NAME=spring-petclinic"},
+				VarFile:   "testdata/template.vars",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid template variables",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				FilePath:  "testdata/workload-template.yaml",
+				Vars:      []string{"NAME"},
+				VarFile:   "testdata/app.env",
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidArrayValue("NAME", flags.VarFlagName, 0),
+				validation.ErrInvalidValueWithDetail("line 5", flags.VarFileFlagName, "expected KEY=value"),
+			),
+		},
+		{
+			Name: "template variables without file",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				Vars:      []string{"NAME=spring-petclinic"},
+			},
+			ExpectFieldErrors: validation.ErrMissingFieldWithDetail(flags.FilePathFlagName, "--var and --var-file are only supported with a workload file"),
+		},
+		{
+			Name: "overlays",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				FilePath:  "testdata/workload.yaml",
+				Overlays:  []string{"testdata/overlays/prod.yaml", "testdata/overlays/release.yaml"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "missing overlay",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				FilePath:  "testdata/workload.yaml",
+				Overlays:  []string{"testdata/overlays/missing.yaml"},
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("testdata/overlays/missing.yaml", flags.OverlayFlagName, "stat testdata/overlays/missing.yaml: no such file or directory"),
+		},
+		{
+			Name: "overlays without file",
+			Validatable: &commands.WorkloadOptions{
+				Namespace: "default",
+				Name:      "my-resource",
+				Overlays:  []string{"testdata/overlays/prod.yaml"},
+			},
+			ExpectFieldErrors: validation.ErrMissingFieldWithDetail(flags.FilePathFlagName, "--overlay is only supported with a workload file"),
+		},
+		{
+			Name: "missing build env file",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:    "default",
+				Name:         "my-resource",
+				BuildEnvFile: "testdata/missing.env",
+			},
+			ExpectFieldErrors: validation.ErrInvalidValueWithDetail("testdata/missing.env", flags.BuildEnvFileFlagName, "open testdata/missing.env: no such file or directory"),
+		},
+		{
+			Name: "env from secrets and configmaps",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:             "default",
+				Name:                  "my-resource",
+				EnvFromSecret:         []string{"PASSWORD=my-secret:password"},
+				EnvFromConfigMap:      []string{"LOG_LEVEL=my-config:log-level"},
+				BuildEnvFromSecret:    []string{"TOKEN=my-secret:token"},
+				BuildEnvFromConfigMap: []string{"BP_JVM_VERSION=my-config:jvm"},
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid env from secrets and configmaps",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:             "default",
+				Name:                  "my-resource",
+				EnvFromSecret:         []string{"PASSWORD=my-secret"},
+				EnvFromConfigMap:      []string{"LOG_LEVEL"},
+				BuildEnvFromSecret:    []string{"=my-secret:token"},
+				BuildEnvFromConfigMap: []string{"BP_JVM_VERSION=:jvm"},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidArrayValue("PASSWORD=secretKeyRef:my-secret", flags.EnvFromSecretFlagName, 0),
+				validation.ErrInvalidArrayValue("LOG_LEVEL", flags.EnvFromConfigMapFlagName, 0),
+				validation.ErrInvalidArrayValue("=secretKeyRef:my-secret:token", flags.BuildEnvFromSecretFlagName, 0),
+				validation.ErrInvalidArrayValue("BP_JVM_VERSION=configMapKeyRef::jvm", flags.BuildEnvFromConfigMapFlagName, 0),
+			),
+		},
+		{
+			Name: "invalid secret and configmap names",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:        "default",
+				Name:             "my-resource",
+				EnvFromSecret:    []string{"PASSWORD=My_Secret:password"},
+				EnvFromConfigMap: []string{"LOG_LEVEL=my.config:level"},
+			},
+			ExpectFieldErrors: validation.FieldErrors{}.Also(
+				validation.ErrInvalidArrayValue("My_Secret", flags.EnvFromSecretFlagName, 0),
+				validation.ErrInvalidArrayValue("my.config", flags.EnvFromConfigMapFlagName, 0),
+			),
+		},
 		{
 			Name: "params",
 			Validatable: &commands.WorkloadOptions{
@@ -470,11 +612,335 @@ func TestWorkloadOptionsValidate(t *testing.T) {
 			},
 			ExpectFieldErrors: validation.ErrInvalidValue("ports_json={\"name\": \"smtp\", \"port\": 1026", flags.ParamYamlFlagName+"[1]"),
 		},
+		{
+			Name: "server side apply",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:      "default",
+				Name:           "my-resource",
+				ServerSide:     true,
+				ForceConflicts: true,
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "force conflicts without server side apply",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:      "default",
+				Name:           "my-resource",
+				ForceConflicts: true,
+			},
+			ExpectFieldErrors: validation.ErrMissingFieldWithDetail(flags.ServerSideFlagName, "--force-conflicts is only supported with server-side apply"),
+		},
+		{
+			Name: "tail output json",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:  "default",
+				Name:       "my-resource",
+				TailOutput: "json",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "invalid tail output",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:  "default",
+				Name:       "my-resource",
+				TailOutput: "yaml",
+			},
+			ExpectFieldErrors: validation.EnumInvalidValue("yaml", flags.TailOutputFlagName, []string{"json"}),
+		},
+		{
+			Name: "source tarball",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:     "default",
+				Name:          "my-resource",
+				LocalPath:     ".",
+				SourceTarball: "source.tar",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "source tarball and oci layout",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:       "default",
+				Name:            "my-resource",
+				LocalPath:       ".",
+				SourceTarball:   "source.tar",
+				SourceOCILayout: "layout",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.SourceTarballFlagName, flags.SourceOCILayoutFlagName),
+		},
+		{
+			Name: "source oci layout without local path",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:       "default",
+				Name:            "my-resource",
+				SourceOCILayout: "layout",
+			},
+			ExpectFieldErrors: validation.ErrMissingFieldWithDetail(flags.LocalPathFlagName, "--source-oci-layout is only supported with local source"),
+		},
+		{
+			Name: "source signing key without local path",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:        "default",
+				Name:             "my-resource",
+				SourceSigningKey: "signing.key",
+			},
+			ExpectFieldErrors: validation.ErrMissingFieldWithDetail(flags.LocalPathFlagName, "--source-signing-key is only supported with local source"),
+		},
+		{
+			Name: "source signing key with tarball",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:        "default",
+				Name:             "my-resource",
+				LocalPath:        ".",
+				SourceSigningKey: "signing.key",
+				SourceTarball:    "source.tar",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.SourceSigningKeyFlagName, flags.SourceTarballFlagName),
+		},
+		{
+			Name: "registry credentials",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:        "default",
+				Name:             "my-resource",
+				RegistryUsername: "robot",
+				RegistryPassword: "s3cret",
+			},
+			ShouldValidate: true,
+		},
+		{
+			Name: "registry password without username",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:        "default",
+				Name:             "my-resource",
+				RegistryPassword: "s3cret",
+			},
+			ExpectFieldErrors: validation.ErrMissingField(flags.RegistryUsernameFlagName),
+		},
+		{
+			Name: "registry credentials and config",
+			Validatable: &commands.WorkloadOptions{
+				Namespace:        "default",
+				Name:             "my-resource",
+				RegistryUsername: "robot",
+				RegistryConfig:   "config.json",
+			},
+			ExpectFieldErrors: validation.ErrMultipleOneOf(flags.RegistryUsernameFlagName, flags.RegistryConfigFlagName),
+		},
 	}
 
 	table.Run(t)
 }
 
+func TestWorkloadOptionsRegistryOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *commands.WorkloadOptions
+		env      map[string]string
+		expected source.RegistryOptions
+	}{{
+		name: "flags",
+		opts: &commands.WorkloadOptions{
+			RegistryCACerts:     []string{"ca.crt"},
+			RegistryVerifyCerts: true,
+			RegistryUsername:    "robot",
+			RegistryPassword:    "s3cret",
+		},
+		env: map[string]string{
+			commands.RegistryUsernameEnv: "env-robot",
+			commands.RegistryPasswordEnv: "env-s3cret",
+		},
+		expected: source.RegistryOptions{
+			CACertPaths: []string{"ca.crt"},
+			VerifyCerts: true,
+			Username:    "robot",
+			Password:    "s3cret",
+		},
+	}, {
+		name: "env",
+		opts: &commands.WorkloadOptions{},
+		env: map[string]string{
+			commands.RegistryUsernameEnv: "env-robot",
+			commands.RegistryPasswordEnv: "env-s3cret",
+		},
+		expected: source.RegistryOptions{
+			Username: "env-robot",
+			Password: "env-s3cret",
+		},
+	}, {
+		name: "registry config ignores env",
+		opts: &commands.WorkloadOptions{
+			RegistryConfig: "config.json",
+		},
+		env: map[string]string{
+			commands.RegistryUsernameEnv: "env-robot",
+			commands.RegistryPasswordEnv: "env-s3cret",
+		},
+		expected: source.RegistryOptions{
+			ConfigFile: "config.json",
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			if diff := cmp.Diff(test.expected, test.opts.RegistryOptions()); diff != "" {
+				t.Errorf("RegistryOptions() (-expected, +actual) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadOptionsSourceProvenance(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	git := func(t *testing.T, dir string, args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	newRepo := func(t *testing.T) string {
+		dir := t.TempDir()
+		git(t, dir, "init", "-q")
+		git(t, dir, "config", "user.email", "dev@example.com")
+		git(t, dir, "config", "user.name", "Dev")
+		utilruntime.Must(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+		git(t, dir, "add", ".")
+		git(t, dir, "commit", "-q", "-m", "initial")
+		return dir
+	}
+	currentUser, err := user.Current()
+	utilruntime.Must(err)
+
+	t.Run("clean repository", func(t *testing.T) {
+		dir := newRepo(t)
+		expected := source.Provenance{
+			GitCommit: git(t, dir, "rev-parse", "HEAD"),
+			Uploader:  "dev@example.com",
+			LocalPath: dir,
+		}
+		opts := &commands.WorkloadOptions{LocalPath: dir}
+		if diff := cmp.Diff(expected, opts.SourceProvenance(context.Background(), cli.NewDefaultConfig("test", runtime.NewScheme()))); diff != "" {
+			t.Errorf("SourceProvenance() (-expected, +actual) = %s", diff)
+		}
+	})
+
+	t.Run("uncommitted changes", func(t *testing.T) {
+		dir := newRepo(t)
+		utilruntime.Must(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644))
+		expected := source.Provenance{
+			GitCommit: git(t, dir, "rev-parse", "HEAD"),
+			GitDirty:  true,
+			Uploader:  "dev@example.com",
+			LocalPath: dir,
+		}
+		opts := &commands.WorkloadOptions{LocalPath: dir}
+		if diff := cmp.Diff(expected, opts.SourceProvenance(context.Background(), cli.NewDefaultConfig("test", runtime.NewScheme()))); diff != "" {
+			t.Errorf("SourceProvenance() (-expected, +actual) = %s", diff)
+		}
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		expected := source.Provenance{
+			Uploader:  currentUser.Username,
+			LocalPath: "testdata/hello.go.jar",
+		}
+		opts := &commands.WorkloadOptions{LocalPath: "testdata/hello.go.jar"}
+		if diff := cmp.Diff(expected, opts.SourceProvenance(context.Background(), cli.NewDefaultConfig("test", runtime.NewScheme()))); diff != "" {
+			t.Errorf("SourceProvenance() (-expected, +actual) = %s", diff)
+		}
+	})
+}
+
+func TestWorkloadOptionsEnvFileVars(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), "app.env")
+	utilruntime.Must(os.WriteFile(envFile, []byte("LOG_LEVEL=debug\nOLD_SETTING-\n"), 0644))
+
+	cmd := &cobra.Command{}
+	ctx := cli.WithCommand(context.Background(), cmd)
+	opts := &commands.WorkloadOptions{
+		Namespace: "default",
+		Name:      "my-workload",
+		EnvFile:   envFile,
+	}
+	if errs := opts.Validate(ctx); len(errs) != 0 {
+		t.Fatalf("Validate() errored %v", errs)
+	}
+	expected := []parsers.DotEnvVar{
+		{Name: "LOG_LEVEL", Value: "debug", Line: 1},
+		{Name: "OLD_SETTING", Delete: true, Line: 2},
+	}
+	if diff := cmp.Diff(expected, opts.EnvFileVars); diff != "" {
+		t.Errorf("Validate() EnvFileVars (-expected, +actual) = %s", diff)
+	}
+
+	// the file is not read again once validated
+	utilruntime.Must(os.Remove(envFile))
+	workload := &cartov1alpha1.Workload{
+		Spec: cartov1alpha1.WorkloadSpec{
+			Env: []corev1.EnvVar{{Name: "OLD_SETTING", Value: "old"}},
+		},
+	}
+	opts.ApplyOptionsToWorkload(ctx, workload)
+	if diff := cmp.Diff([]corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}, workload.Spec.Env); diff != "" {
+		t.Errorf("ApplyOptionsToWorkload() env (-expected, +actual) = %s", diff)
+	}
+
+	if errs := opts.Validate(ctx); len(errs) == 0 {
+		t.Errorf("Validate() expected an error for the missing env file")
+	}
+}
+
+func TestWorkloadOptionsOutputConfigs(t *testing.T) {
+	tests := []struct {
+		name           string
+		tailOutput     string
+		expectedStdout string
+		expectedStderr string
+	}{{
+		name:           "text logs",
+		expectedStdout: "Waiting for workload\nlog line\n",
+	}, {
+		name:           "json logs",
+		tailOutput:     "json",
+		expectedStdout: "{\"message\":\"log line\"}\n",
+		expectedStderr: "Waiting for workload\n",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			c := cli.NewDefaultConfig("test", runtime.NewScheme())
+			c.Stdout = stdout
+			c.Stderr = stderr
+
+			opts := &commands.WorkloadOptions{TailOutput: test.tailOutput}
+			out, tailConfig := opts.OutputConfigs(c)
+			out.Printf("Waiting for workload\n")
+			if test.tailOutput == "json" {
+				fmt.Fprintf(tailConfig.Stdout, "{\"message\":\"log line\"}\n")
+			} else {
+				fmt.Fprintf(tailConfig.Stdout, "log line\n")
+			}
+
+			if diff := cmp.Diff(test.expectedStdout, stdout.String()); diff != "" {
+				t.Errorf("OutputConfigs() stdout (-expected, +actual) = %s", diff)
+			}
+			if diff := cmp.Diff(test.expectedStderr, stderr.String()); diff != "" {
+				t.Errorf("OutputConfigs() stderr (-expected, +actual) = %s", diff)
+			}
+			if c.Stdout != stdout {
+				t.Errorf("OutputConfigs() changed the stdout of the config")
+			}
+		})
+	}
+}
+
 func TestWorkloadOptionsApplyOptionsToWorkload(t *testing.T) {
 	defaultNamespace := "default"
 	workloadName := "my-workload"
@@ -1034,6 +1500,104 @@ func TestWorkloadOptionsApplyOptionsToWorkload(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add/update/remove env from env files",
+			args: []string{flags.EnvFileFlagName, "testdata/app.env", flags.EnvFlagName, "LOG_LEVEL=info", flags.BuildEnvFileFlagName, "testdata/app.env"},
+			input: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Env: []corev1.EnvVar{
+						{Name: "OLD_SETTING", Value: "old"},
+						{Name: "MOTTO", Value: "less is more"},
+					},
+					Image: "ubuntu:bionic",
+				},
+			},
+			expected: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Env: []corev1.EnvVar{
+						{Name: "MOTTO", Value: `keep it "simple"`},
+						{Name: "LOG_LEVEL", Value: "info"},
+						{Name: "GREETING", Value: "hello\nworld"},
+					},
+					Build: &cartov1alpha1.WorkloadBuild{
+						Env: []corev1.EnvVar{
+							{Name: "LOG_LEVEL", Value: "debug"},
+							{Name: "GREETING", Value: "hello\nworld"},
+							{Name: "MOTTO", Value: `keep it "simple"`},
+						},
+					},
+					Image: "ubuntu:bionic",
+				},
+			},
+		},
+		{
+			name: "add/update env from secrets and configmaps",
+			args: []string{flags.EnvFromSecretFlagName, "PASSWORD=my-secret:password", flags.EnvFromConfigMapFlagName, "FOO=my-config:foo", flags.BuildEnvFromSecretFlagName, "TOKEN=my-secret:token", flags.BuildEnvFromConfigMapFlagName, "BAR=my-config:bar"},
+			input: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Env: []corev1.EnvVar{
+						{Name: "FOO", Value: "foo"},
+					},
+					Build: &cartov1alpha1.WorkloadBuild{
+						Env: []corev1.EnvVar{
+							{Name: "BAR", Value: "bar"},
+						},
+					},
+					Image: "ubuntu:bionic",
+				},
+			},
+			expected: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Env: []corev1.EnvVar{
+						{Name: "FOO", ValueFrom: &corev1.EnvVarSource{
+							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+								Key:                  "foo",
+							},
+						}},
+						{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+								Key:                  "password",
+							},
+						}},
+					},
+					Build: &cartov1alpha1.WorkloadBuild{
+						Env: []corev1.EnvVar{
+							{Name: "BAR", ValueFrom: &corev1.EnvVarSource{
+								ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+									Key:                  "bar",
+								},
+							}},
+							{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+									Key:                  "token",
+								},
+							}},
+						},
+					},
+					Image: "ubuntu:bionic",
+				},
+			},
+		},
 		{
 			name: "workload with optional flags",
 			args: []string{flags.GitRepoFlagName, gitRepo, flags.GitBranchFlagName, gitBranch, flags.AppFlagName, appName, flags.TypeFlagName, typeName, flags.ParamFlagName, "foo=bar", flags.ParamFlagName, "bleep=bloop", flags.ParamFlagName, "bleep-", flags.EnvFlagName, "FOO=bar", flags.BuildEnvFlagName, "BAR=baz", flags.ServiceRefFlagName, "database=services.tanzu.vmware.com/v1alpha1:PostgreSQL:my-prod-db", flags.LimitCPUFlagName, "500m", flags.LimitMemoryFlagName, "1Gi", flags.LabelFlagName, "build.tanzu.vmware.com/supply-chain=custom", flags.YesFlagName},
@@ -1583,6 +2147,8 @@ func TestWorkloadOptionsApplyOptionsToWorkload(t *testing.T) {
 		opts := &commands.WorkloadOptions{}
 		opts.DefineFlags(ctx, c, cmd)
 		cmd.ParseFlags(test.args)
+		// the env files are read when the options are validated
+		opts.Validate(ctx)
 
 		actual := test.input.DeepCopy()
 		opts.ApplyOptionsToWorkload(ctx, actual)
@@ -1601,6 +2167,16 @@ func TestWorkloadOptionsPublishLocalSource(t *testing.T) {
 	u, err := url.Parse(registry.URL)
 	utilruntime.Must(err)
 	registryHost := u.Host
+	outputDir := t.TempDir()
+	signingKey := filepath.Join(outputDir, "signing.key")
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	utilruntime.Must(err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	utilruntime.Must(err)
+	utilruntime.Must(os.WriteFile(signingKey, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	signedSource := filepath.Join(outputDir, "signed-source")
+	utilruntime.Must(os.Mkdir(signedSource, 0755))
+	utilruntime.Must(os.WriteFile(filepath.Join(signedSource, "main.go"), []byte("package main\n"), 0644))
 
 	tests := []struct {
 		name           string
@@ -1617,6 +2193,7 @@ func TestWorkloadOptionsPublishLocalSource(t *testing.T) {
 		expectedOutput: `
 Publishing source in "testdata/local-source" to "` + registryHost + `/hello:source"...
 Published source
+1 files, 6B (137B compressed, 137B uploaded)
 `,
 	}, {
 		name:     "jar file",
@@ -1626,6 +2203,7 @@ Published source
 		expectedOutput: `
 Publishing source in "testdata/hello.go.jar" to "` + registryHost + `/hello:source"...
 Published source
+2 files, 248B (349B compressed, 349B uploaded)
 `,
 	}, {
 		name:        "invalid file",
@@ -1640,13 +2218,70 @@ Published source
 		expectedOutput: `
 Publishing source in "testdata/local-source" to "` + registryHost + `/hello:source"...
 Published source
+1 files, 6B (137B compressed, 0B uploaded)
 `,
 	}, {
-		name:           "no local path",
+		name:     "unchanged source",
+		args:     []string{flags.LocalPathFlagName, "testdata/local-source", flags.YesFlagName},
+		input:    fmt.Sprintf("%s/hello:source", registryHost),
+		expected: fmt.Sprintf("%s/hello:source@sha256:%s", registryHost, "111d543b7736846f502387eed53be08c5ceb0a6010faaaf043409702074cf652"),
+		expectedOutput: `
+Source in "testdata/local-source" is unchanged, skipping publish
+`,
+	}, {
+		name:     "unchanged source with signing key",
+		args:     []string{flags.LocalPathFlagName, "testdata/local-source", flags.SourceSigningKeyFlagName, signingKey, flags.YesFlagName},
+		input:    fmt.Sprintf("%s/hello:source", registryHost),
+		expected: fmt.Sprintf("%s/hello:source@sha256:%s", registryHost, "111d543b7736846f502387eed53be08c5ceb0a6010faaaf043409702074cf652"),
+		expectedOutput: `
+Source in "testdata/local-source" is unchanged, skipping publish
+Signed source and attested its provenance
+`,
+	}, {
+		name:     "write tarball",
+		args:     []string{flags.LocalPathFlagName, "testdata/local-source", flags.SourceTarballFlagName, filepath.Join(outputDir, "source.tar"), flags.YesFlagName},
+		input:    "registry.example.com/hello:source",
+		expected: fmt.Sprintf("registry.example.com/hello:source@sha256:%s", "111d543b7736846f502387eed53be08c5ceb0a6010faaaf043409702074cf652"),
+		expectedOutput: `
+Writing source in "testdata/local-source" to tarball "` + filepath.Join(outputDir, "source.tar") + `"...
+Wrote source, import it to "registry.example.com/hello:source" before the workload can be built
+`,
+	}, {
+		name:     "write oci layout",
+		args:     []string{flags.LocalPathFlagName, "testdata/local-source", flags.SourceOCILayoutFlagName, filepath.Join(outputDir, "layout"), flags.YesFlagName},
+		input:    "registry.example.com/hello:source",
+		expected: fmt.Sprintf("registry.example.com/hello:source@sha256:%s", "111d543b7736846f502387eed53be08c5ceb0a6010faaaf043409702074cf652"),
+		expectedOutput: `
+Writing source in "testdata/local-source" to OCI layout "` + filepath.Join(outputDir, "layout") + `"...
+Wrote source, import it to "registry.example.com/hello:source" before the workload can be built
+`,
+	}, {
+		name:     "sign source",
+		args:     []string{flags.LocalPathFlagName, signedSource, flags.SourceSigningKeyFlagName, signingKey, flags.YesFlagName},
+		input:    fmt.Sprintf("%s/signed:source", registryHost),
+		expected: fmt.Sprintf("%s/signed:source@sha256:%s", registryHost, "488be5e65c6dc9e78fade714c6a6a20add059ca0f2610bb3ce992dd72bb482a2"),
+		expectedOutput: `
+Publishing source in "` + signedSource + `" to "` + registryHost + `/signed:source"...
+Published source
+1 files, 13B (142B compressed, 142B uploaded)
+Signed source and attested its provenance
+`,
+	}, {
+		name:        "invalid signing key",
+		args:        []string{flags.LocalPathFlagName, "testdata/local-source", flags.SourceSigningKeyFlagName, "testdata/missing.key", flags.YesFlagName},
+		input:       fmt.Sprintf("%s/signed:invalid", registryHost),
+		shouldError: true,
+	}, {
+		name:           "no local path",
 		args:           []string{},
 		input:          fmt.Sprintf("%s/hello:source", registryHost),
 		expected:       fmt.Sprintf("%s/hello:source", registryHost),
 		expectedOutput: "",
+	}, {
+		name:        "missing registry ca cert",
+		args:        []string{flags.LocalPathFlagName, "testdata/local-source", flags.RegistryCACertFlagName, "testdata/missing.crt", flags.YesFlagName},
+		input:       fmt.Sprintf("%s/hello:source", registryHost),
+		shouldError: true,
 	}, {
 		name:        "publish local source with error",
 		args:        []string{flags.LocalPathFlagName, "testdata/local-source", flags.YesFlagName},
@@ -1808,12 +2443,19 @@ func TestWorkloadOptionsUpdate(t *testing.T) {
 	c.Client = clitesting.NewFakeCliClient(clitesting.NewFakeClient(scheme))
 
 	tests := []struct {
-		name           string
-		args           []string
-		givenWorkload  *cartov1alpha1.Workload
-		shouldError    bool
-		expectedOutput string
-		withReactors   []clitesting.ReactionFunc
+		name            string
+		args            []string
+		givenWorkload   *cartov1alpha1.Workload
+		shouldError     bool
+		expectedOutput  string
+		withReactors    []clitesting.ReactionFunc
+		retryOnConflict bool
+		// staleWorkload is used as the current workload instead of the given workload
+		staleWorkload *cartov1alpha1.Workload
+		// publishedSourceImage is set as the source image, as published from a local path
+		publishedSourceImage string
+		expectedLabels       map[string]string
+		expectedSource       *cartov1alpha1.Source
 	}{
 		{
 			name: "Update workload successfully",
@@ -1894,6 +2536,186 @@ Updated workload "my-workload"
 			},
 			shouldError: true,
 		},
+		{
+			name:            "Update conflict with retry",
+			args:            []string{flags.LabelFlagName, "NEW=value", flags.YesFlagName},
+			retryOnConflict: true,
+			withReactors: []clitesting.ReactionFunc{
+				conflictOnce(workloadName),
+			},
+			givenWorkload: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+				},
+			},
+			expectedOutput: `
+Update workload:
+  1,  1   |---
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+      5 + |  labels:
+      6 + |    NEW: value
+  5,  7   |  name: my-workload
+  6,  8   |  namespace: default
+  7,  9   |spec:
+  8, 10   |  image: ubuntu:bionic
+
+Workload "my-workload" was modified by another user, retrying update
+Updated workload "my-workload"
+`,
+			expectedLabels: map[string]string{"NEW": "value"},
+		},
+		{
+			name:            "Update conflict with retries exhausted",
+			args:            []string{flags.LabelFlagName, "NEW=value", flags.YesFlagName},
+			retryOnConflict: true,
+			withReactors: []clitesting.ReactionFunc{
+				clitesting.InduceFailure("update", "Workload", clitesting.InduceFailureOpts{
+					Error: apierrs.NewConflict(schema.GroupResource{Group: "carto.run", Resource: "workloads"}, workloadName, fmt.Errorf("induced conflict")),
+				}),
+			},
+			givenWorkload: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name:            "Update stale workload with retry",
+			args:            []string{flags.LabelFlagName, "NEW=value", flags.YesFlagName},
+			retryOnConflict: true,
+			givenWorkload: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+					Labels: map[string]string{
+						"controller": "value",
+					},
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+				},
+			},
+			staleWorkload: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       defaultNamespace,
+					Name:            workloadName,
+					ResourceVersion: "1",
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+				},
+			},
+			expectedOutput: `
+Update workload:
+  1,  1   |---
+  2,  2   |apiVersion: carto.run/v1alpha1
+  3,  3   |kind: Workload
+  4,  4   |metadata:
+      5 + |  labels:
+      6 + |    NEW: value
+  5,  7   |  name: my-workload
+  6,  8   |  namespace: default
+  7,  9   |spec:
+  8, 10   |  image: ubuntu:bionic
+
+Workload "my-workload" was modified by another user, retrying update
+Updated workload "my-workload"
+`,
+			expectedLabels: map[string]string{"NEW": "value", "controller": "value"},
+		},
+		{
+			name:            "Update stale workload with retry and conflicting changes",
+			args:            []string{flags.ImageFlagName, "ubuntu:jammy", flags.YesFlagName},
+			retryOnConflict: true,
+			givenWorkload: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:focal",
+				},
+			},
+			staleWorkload: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       defaultNamespace,
+					Name:            workloadName,
+					ResourceVersion: "1",
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Image: "ubuntu:bionic",
+				},
+			},
+			expectedOutput: `
+Update workload:
+...
+  4,  4   |metadata:
+  5,  5   |  name: my-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8     - |  image: ubuntu:bionic
+      8 + |  image: ubuntu:jammy
+
+Workload "my-workload" was modified by another user, retrying update
+Update workload "my-workload":
+...
+  4,  4   |metadata:
+  5,  5   |  name: my-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8     - |  image: ubuntu:focal
+      8 + |  image: ubuntu:jammy
+
+Updated workload "my-workload"
+`,
+		},
+		{
+			name:                 "Update local source conflict with retry",
+			args:                 []string{flags.LocalPathFlagName, "testdata/local-source", flags.SourceImageFlagName, "registry.example/hello:source", flags.YesFlagName},
+			retryOnConflict:      true,
+			publishedSourceImage: "registry.example/hello:source@sha256:2222222222222222222222222222222222222222222222222222222222222222",
+			withReactors: []clitesting.ReactionFunc{
+				conflictOnce(workloadName),
+			},
+			givenWorkload: &cartov1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: defaultNamespace,
+					Name:      workloadName,
+				},
+				Spec: cartov1alpha1.WorkloadSpec{
+					Source: &cartov1alpha1.Source{
+						Image: "registry.example/hello:source@sha256:1111111111111111111111111111111111111111111111111111111111111111",
+					},
+				},
+			},
+			expectedOutput: `
+Update workload:
+...
+  5,  5   |  name: my-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8,  8   |  source:
+  9     - |    image: registry.example/hello:source@sha256:1111111111111111111111111111111111111111111111111111111111111111
+      9 + |    image: registry.example/hello:source@sha256:2222222222222222222222222222222222222222222222222222222222222222
+
+Workload "my-workload" was modified by another user, retrying update
+Updated workload "my-workload"
+`,
+			expectedSource: &cartov1alpha1.Source{
+				Image: "registry.example/hello:source@sha256:2222222222222222222222222222222222222222222222222222222222222222",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1923,13 +2745,20 @@ Updated workload "my-workload"
 			if err != nil {
 				t.Errorf("Update() errored %v", err)
 			}
+			if test.staleWorkload != nil {
+				currentWorkload = test.staleWorkload
+			}
 
 			opts := &commands.WorkloadOptions{}
 			opts.DefineFlags(ctx, c, cmd)
 			cmd.ParseFlags(test.args)
+			opts.RetryOnConflict = test.retryOnConflict
 
 			workload := currentWorkload.DeepCopy()
 			opts.ApplyOptionsToWorkload(ctx, workload)
+			if test.publishedSourceImage != "" {
+				workload.Spec.Source.Image = test.publishedSourceImage
+			}
 			_, err = opts.Update(ctx, c, currentWorkload, workload)
 
 			if err != nil && !test.shouldError {
@@ -1945,10 +2774,41 @@ Updated workload "my-workload"
 			if diff := cmp.Diff(strings.TrimSpace(test.expectedOutput), strings.TrimSpace(output.String())); diff != "" {
 				t.Errorf("Update() (-want, +got) = %s", diff)
 			}
+
+			if test.expectedLabels != nil {
+				actual := &cartov1alpha1.Workload{}
+				if err := c.Get(ctx, client.ObjectKey{Namespace: defaultNamespace, Name: workloadName}, actual); err != nil {
+					t.Errorf("Get() errored %v", err)
+				}
+				if diff := cmp.Diff(test.expectedLabels, actual.Labels); diff != "" {
+					t.Errorf("Update() labels (-want, +got) = %s", diff)
+				}
+			}
+			if test.expectedSource != nil {
+				actual := &cartov1alpha1.Workload{}
+				if err := c.Get(ctx, client.ObjectKey{Namespace: defaultNamespace, Name: workloadName}, actual); err != nil {
+					t.Errorf("Get() errored %v", err)
+				}
+				if diff := cmp.Diff(test.expectedSource, actual.Spec.Source); diff != "" {
+					t.Errorf("Update() source (-want, +got) = %s", diff)
+				}
+			}
 		})
 	}
 }
 
+// conflictOnce fails the first update of the named workload with a conflict
+func conflictOnce(name string) clitesting.ReactionFunc {
+	conflicted := false
+	return func(action clitesting.Action) (bool, runtime.Object, error) {
+		if conflicted || !action.Matches("update", "Workload") {
+			return false, nil, nil
+		}
+		conflicted = true
+		return true, nil, apierrs.NewConflict(schema.GroupResource{Group: "carto.run", Resource: "workloads"}, name, fmt.Errorf("induced conflict"))
+	}
+}
+
 func TestLoadInputWorkload(t *testing.T) {
 	scheme := runtime.NewScheme()
 	c := cli.NewDefaultConfig("test", scheme)
@@ -2000,6 +2860,12 @@ spec:
 			stdin:       c.Stdin,
 			shouldError: true,
 		},
+		{
+			name:        "error loading file with multiple workloads",
+			file:        "testdata/workloads.yaml",
+			stdin:       c.Stdin,
+			shouldError: true,
+		},
 		{
 			name: "error with workload type",
 			file: "-",
@@ -2049,3 +2915,295 @@ spec:
 		})
 	}
 }
+
+func TestLoadInputWorkloads(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		vars        []string
+		varFile     string
+		stdin       io.Reader
+		expected    []string
+		shouldError bool
+	}{
+		{
+			name:     "loads single workload from file",
+			file:     "testdata/workload.yaml",
+			expected: []string{"spring-petclinic"},
+		},
+		{
+			name:     "loads multiple workloads from file",
+			file:     "testdata/workloads.yaml",
+			expected: []string{"spring-petclinic", "spring-petclinic-api"},
+		},
+		{
+			name:     "loads workloads from directory",
+			file:     "testdata/workloads",
+			expected: []string{"spring-petclinic-api", "spring-petclinic"},
+		},
+		{
+			name: "loads multiple workloads from stdin",
+			file: "-",
+			stdin: strings.NewReader(`
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: spring-petclinic
+---
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: spring-petclinic-api
+`),
+			expected: []string{"spring-petclinic", "spring-petclinic-api"},
+		},
+		{
+			name:     "renders template with variables",
+			file:     "testdata/workload-template.yaml",
+			vars:     []string{"NAME=spring-petclinic-feature", "BRANCH=feature"},
+			expected: []string{"spring-petclinic-feature"},
+		},
+		{
+			name:     "renders template with var file",
+			file:     "testdata/workload-template.yaml",
+			varFile:  "testdata/template.vars",
+			vars:     []string{"NAME=spring-petclinic-fix"},
+			expected: []string{"spring-petclinic-fix"},
+		},
+		{
+			name: "renders template from stdin",
+			file: "-",
+			vars: []string{"NAME=spring-petclinic"},
+			stdin: strings.NewReader(`
+apiVersion: carto.run/v1alpha1
+kind: Workload
+metadata:
+  name: ${NAME}
+`),
+			expected: []string{"spring-petclinic"},
+		},
+		{
+			name:        "error rendering template with unresolved variables",
+			file:        "testdata/workload-template.yaml",
+			vars:        []string{"BRANCH=feature"},
+			shouldError: true,
+		},
+		{
+			name:        "error loading non-existent file",
+			file:        "testdata/workload1.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error loading directory without workloads",
+			file:        "testdata/local-source",
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := &commands.WorkloadOptions{
+				FilePath: test.file,
+				Vars:     test.vars,
+				VarFile:  test.varFile,
+			}
+			// the var file is read when the options are validated
+			opts.Validate(context.Background())
+
+			workloads, err := opts.LoadInputWorkloads(test.stdin)
+
+			if (err == nil) == test.shouldError {
+				t.Errorf("LoadInputWorkloads() shouldErr %t, got %v", test.shouldError, err)
+			} else if test.shouldError {
+				return
+			}
+			names := []string{}
+			for _, w := range workloads {
+				names = append(names, w.Name)
+			}
+			if diff := cmp.Diff(test.expected, names); diff != "" {
+				t.Errorf("LoadInputWorkloads() (-expected, +actual) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadInputWorkloadsWithOverlays(t *testing.T) {
+	petclinic := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "spring-petclinic",
+			Labels: map[string]string{
+				apis.AppPartOfLabelName:    "spring-petclinic",
+				apis.WorkloadTypeLabelName: "web",
+			},
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Env: []corev1.EnvVar{
+				{Name: "SPRING_PROFILES_ACTIVE", Value: "mysql"},
+			},
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+			Source: &cartov1alpha1.Source{
+				Git: &cartov1alpha1.GitSource{
+					URL: "https://github.com/spring-projects/spring-petclinic.git",
+					Ref: cartov1alpha1.GitRef{
+						Branch: "main",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		file        string
+		overlays    []string
+		expected    []*cartov1alpha1.Workload
+		shouldError bool
+	}{
+		{
+			name:     "strategic merge patch",
+			file:     "testdata/workload.yaml",
+			overlays: []string{"testdata/overlays/prod.yaml"},
+			expected: []*cartov1alpha1.Workload{
+				func() *cartov1alpha1.Workload {
+					w := petclinic.DeepCopy()
+					w.Spec.Env = []corev1.EnvVar{
+						{Name: "SPRING_PROFILES_ACTIVE", Value: "postgres"},
+						{Name: "LOG_LEVEL", Value: "warn"},
+					}
+					w.Spec.Resources.Limits[corev1.ResourceMemory] = resource.MustParse("2Gi")
+					return w
+				}(),
+			},
+		},
+		{
+			name:     "json patch",
+			file:     "testdata/workload.yaml",
+			overlays: []string{"testdata/overlays/release.yaml"},
+			expected: []*cartov1alpha1.Workload{
+				func() *cartov1alpha1.Workload {
+					w := petclinic.DeepCopy()
+					w.Spec.Source.Git.Ref.Branch = "release"
+					w.Spec.Params = []cartov1alpha1.Param{
+						{Name: "replicas", Value: apiextensionsv1.JSON{Raw: []byte("3")}},
+					}
+					return w
+				}(),
+			},
+		},
+		{
+			name:     "overlays applied in order",
+			file:     "testdata/workload.yaml",
+			overlays: []string{"testdata/overlays/release.yaml", "testdata/overlays/prod.yaml"},
+			expected: []*cartov1alpha1.Workload{
+				func() *cartov1alpha1.Workload {
+					w := petclinic.DeepCopy()
+					w.Spec.Env = []corev1.EnvVar{
+						{Name: "SPRING_PROFILES_ACTIVE", Value: "postgres"},
+						{Name: "LOG_LEVEL", Value: "warn"},
+					}
+					w.Spec.Resources.Limits[corev1.ResourceMemory] = resource.MustParse("2Gi")
+					w.Spec.Source.Git.Ref.Branch = "release"
+					w.Spec.Params = []cartov1alpha1.Param{
+						{Name: "replicas", Value: apiextensionsv1.JSON{Raw: []byte("3")}},
+					}
+					return w
+				}(),
+			},
+		},
+		{
+			name:     "patches for named workloads",
+			file:     "testdata/workloads.yaml",
+			overlays: []string{"testdata/overlays/tiers.yaml"},
+			expected: []*cartov1alpha1.Workload{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "spring-petclinic",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:    "spring-petclinic",
+							apis.WorkloadTypeLabelName: "web",
+							"tier":                     "frontend",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "spring-petclinic-api",
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:    "spring-petclinic",
+							apis.WorkloadTypeLabelName: "web",
+							"tier":                     "backend",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic-api.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "error patching unknown workload",
+			file:        "testdata/workloads.yaml",
+			overlays:    []string{"testdata/overlays/unknown.yaml"},
+			shouldError: true,
+		},
+		{
+			name:        "error loading invalid overlay",
+			file:        "testdata/workload.yaml",
+			overlays:    []string{"testdata/app.env"},
+			shouldError: true,
+		},
+		{
+			name:        "error loading missing overlay",
+			file:        "testdata/workload.yaml",
+			overlays:    []string{"testdata/overlays/missing.yaml"},
+			shouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := &commands.WorkloadOptions{
+				FilePath: test.file,
+				Overlays: test.overlays,
+			}
+
+			workloads, err := opts.LoadInputWorkloads(nil)
+
+			if (err == nil) == test.shouldError {
+				t.Errorf("LoadInputWorkloads() shouldErr %t, got %v", test.shouldError, err)
+			} else if test.shouldError {
+				return
+			}
+			if diff := cmp.Diff(test.expected, workloads); diff != "" {
+				t.Errorf("LoadInputWorkloads() (-expected, +actual) = %s", diff)
+			}
+		})
+	}
+}
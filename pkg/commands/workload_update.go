@@ -55,6 +55,9 @@ func (opts *WorkloadUpdateOptions) Validate(ctx context.Context) validation.Fiel
 }
 
 func (opts *WorkloadUpdateOptions) Exec(ctx context.Context, c *cli.Config) error {
+	// the output of the command goes to stderr while the logs are tailed as json to stdout
+	out, tailConfig := opts.OutputConfigs(c)
+	c = out
 	fileWorkload := &cartov1alpha1.Workload{}
 	if opts.FilePath != "" {
 		if err := opts.WorkloadOptions.LoadInputWorkload(c.Stdin, fileWorkload); err != nil {
@@ -124,6 +127,9 @@ func (opts *WorkloadUpdateOptions) Exec(ctx context.Context, c *cli.Config) erro
 	}
 
 	if opts.DryRun {
+		if err := opts.PrintLocalSourceFiles(c); err != nil {
+			return err
+		}
 		cli.DryRunResource(ctx, workload, workload.GetGroupVersionKind())
 		return nil
 	}
@@ -140,7 +146,7 @@ func (opts *WorkloadUpdateOptions) Exec(ctx context.Context, c *cli.Config) erro
 		return err
 	}
 
-	anyTail := opts.Tail || opts.TailTimestamps
+	anyTail := opts.anyTail()
 	if (opts.Yes || okToUpdate) && (opts.Wait || anyTail) {
 		c.Infof("Waiting for workload %q to become ready...\n", opts.Name)
 
@@ -161,7 +167,7 @@ func (opts *WorkloadUpdateOptions) Exec(ctx context.Context, c *cli.Config) erro
 					panic(err)
 				}
 				containers := []string{}
-				return logs.Tail(ctx, c, opts.Namespace, selector, containers, time.Second, opts.TailTimestamps)
+				return logs.Tail(ctx, tailConfig, opts.Namespace, selector, containers, time.Second, opts.TailTimestamps, opts.TailOutput, logs.TailOptions{})
 			})
 		}
 
@@ -216,6 +222,8 @@ Workload configuration options include:
 
 	// Define common flags
 	opts.DefineFlags(ctx, c, cmd)
+	opts.defineRetryOnConflictFlag(cmd)
+	cmd.Flag(cli.StripDash(flags.FilePathFlagName)).Usage = "`file path` containing the description of a single workload, other flags are layered on top of this resource. Use value \"-\" to read from stdin"
 
 	return cmd
 }
@@ -94,6 +94,7 @@ func TestWorkloadUpdateOptionsValidate(t *testing.T) {
 func TestWorkloadUpdateCommand(t *testing.T) {
 	defaultNamespace := "default"
 	workloadName := "my-workload"
+	projectServiceAccountName := "my-service-account"
 
 	scheme := runtime.NewScheme()
 	_ = cartov1alpha1.AddToScheme(scheme)
@@ -352,6 +353,58 @@ status:
 				},
 			},
 		},
+		{
+			Name: "project config keeps git source",
+			Args: []string{workloadName, flags.NamespaceFlagName, defaultNamespace, flags.EnvFlagName, "FOO=bar", flags.YesFlagName},
+			Prepare: func(t *testing.T, ctx context.Context, config *cli.Config, tc *clitesting.CommandTestCase) (context.Context, error) {
+				config.ViperConfigFile = "testdata/project.yaml"
+				return ctx, nil
+			},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Source(
+							&cartov1alpha1.Source{
+								Git: &cartov1alpha1.GitSource{
+									URL: "https://github.com/spring-projects/spring-petclinic.git",
+									Ref: cartov1alpha1.GitRef{
+										Branch: "main",
+									},
+								},
+							},
+						)
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels: map[string]string{
+							apis.AppPartOfLabelName:    "spring-petclinic",
+							apis.WorkloadTypeLabelName: "web",
+						},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						ServiceAccountName: &projectServiceAccountName,
+						Env: []corev1.EnvVar{
+							{
+								Name:  "FOO",
+								Value: "bar",
+							},
+						},
+						Source: &cartov1alpha1.Source{
+							Git: &cartov1alpha1.GitSource{
+								URL: "https://github.com/spring-projects/spring-petclinic.git",
+								Ref: cartov1alpha1.GitRef{
+									Branch: "main",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "conflict during update",
 			Args: []string{workloadName, flags.DebugFlagName, flags.YesFlagName},
@@ -397,6 +450,66 @@ Update workload:
      11 + |    value: "true"
 
 Error: conflict updating workload, the object was modified by another user; please run the update command again
+`,
+		},
+		{
+			Name: "conflict during update with retry",
+			Args: []string{workloadName, flags.DebugFlagName, flags.YesFlagName, flags.RetryOnConflictFlagName},
+			WithReactors: []clitesting.ReactionFunc{
+				conflictOnce(workloadName),
+			},
+			GivenObjects: []client.Object{
+				parent.
+					SpecDie(func(d *diecartov1alpha1.WorkloadSpecDie) {
+						d.Image("ubuntu:bionic")
+					}),
+			},
+			ExpectUpdates: []client.Object{
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+						Labels:    map[string]string{},
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "debug",
+								Value: apiextensionsv1.JSON{Raw: []byte(`"true"`)},
+							},
+						},
+					},
+				},
+				&cartov1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: defaultNamespace,
+						Name:      workloadName,
+					},
+					Spec: cartov1alpha1.WorkloadSpec{
+						Image: "ubuntu:bionic",
+						Params: []cartov1alpha1.Param{
+							{
+								Name:  "debug",
+								Value: apiextensionsv1.JSON{Raw: []byte(`"true"`)},
+							},
+						},
+					},
+				},
+			},
+			ExpectOutput: `
+Update workload:
+...
+  5,  5   |  name: my-workload
+  6,  6   |  namespace: default
+  7,  7   |spec:
+  8,  8   |  image: ubuntu:bionic
+      9 + |  params:
+     10 + |  - name: debug
+     11 + |    value: "true"
+
+Workload "my-workload" was modified by another user, retrying update
+Updated workload "my-workload"
 `,
 		},
 		{
@@ -639,7 +752,7 @@ Workload "my-workload" is ready
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, false).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, false, "", logs.TailOptions{}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -721,7 +834,7 @@ Workload "my-workload" is ready
 
 				tailer := &logs.FakeTailer{}
 				selector, _ := labels.Parse(fmt.Sprintf("%s=%s", cartov1alpha1.WorkloadLabelName, workloadName))
-				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, true).Return(nil).Once()
+				tailer.On("Tail", mock.Anything, "default", selector, []string{}, time.Second, true, "", logs.TailOptions{}).Return(nil).Once()
 				ctx = logs.StashTailer(ctx, tailer)
 
 				return ctx, nil
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/flags"
+)
+
+// SuggestWorkloadEnvNames suggests workload names for the first argument and the runtime and build
+// environment variables of that workload for the following arguments
+func SuggestWorkloadEnvNames(ctx context.Context, c *cli.Config) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return SuggestWorkloadNames(ctx, c)(cmd, args, toComplete)
+		}
+		namespace := cmd.Flag(cli.StripDash(flags.NamespaceFlagName)).Value.String()
+		if namespace == "" {
+			namespace = c.DefaultNamespace()
+		}
+
+		workload := &cartov1alpha1.Workload{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: args[0]}, workload); err != nil {
+			return []string{}, cobra.ShellCompDirectiveError
+		}
+
+		suggestions := []string{}
+		seen := map[string]bool{}
+		env := append([]corev1.EnvVar{}, workload.Spec.Env...)
+		if workload.Spec.Build != nil {
+			env = append(env, workload.Spec.Build.Env...)
+		}
+		for _, e := range env {
+			if !seen[e.Name] {
+				seen[e.Name] = true
+				suggestions = append(suggestions, e.Name)
+			}
+		}
+		return suggestions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
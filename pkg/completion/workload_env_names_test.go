@@ -0,0 +1,120 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
+)
+
+func TestSuggestWorkloadEnvNames(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+	namespace := "default"
+
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workload",
+			Namespace: namespace,
+		},
+		Spec: cartov1alpha1.WorkloadSpec{
+			Env: []corev1.EnvVar{
+				{Name: "FOO", Value: "foo"},
+				{Name: "BAR", Value: "bar"},
+			},
+			Build: &cartov1alpha1.WorkloadBuild{
+				Env: []corev1.EnvVar{
+					{Name: "BAR", Value: "bar"},
+					{Name: "BP_JVM_VERSION", Value: "17"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		args               []string
+		given              []client.Object
+		reactor            clitesting.ReactionFunc
+		sugestions         []string
+		shellCompDirective cobra.ShellCompDirective
+	}{
+		{
+			name:               "workload names",
+			args:               []string{},
+			given:              []client.Object{workload},
+			sugestions:         []string{"my-workload"},
+			shellCompDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name:               "workload env",
+			args:               []string{"my-workload"},
+			given:              []client.Object{workload},
+			sugestions:         []string{"FOO", "BAR", "BP_JVM_VERSION"},
+			shellCompDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name:               "workload not found",
+			args:               []string{"other-workload"},
+			given:              []client.Object{workload},
+			sugestions:         []string{},
+			shellCompDirective: cobra.ShellCompDirectiveError,
+		},
+		{
+			name:               "get error",
+			args:               []string{"my-workload", "FOO"},
+			given:              []client.Object{workload},
+			reactor:            clitesting.InduceFailure("get", "Workload"),
+			sugestions:         []string{},
+			shellCompDirective: cobra.ShellCompDirectiveError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := cli.NewDefaultConfig("test", scheme)
+			client := clitesting.NewFakeClient(scheme, test.given...)
+			if test.reactor != nil {
+				client.AddReactor("*", "*", test.reactor)
+			}
+
+			c.Client = clitesting.NewFakeCliClient(client)
+			cmd := &cobra.Command{}
+			cmd.Flags().String("namespace", namespace, "")
+
+			suggestions, directive := completion.SuggestWorkloadEnvNames(ctx, c)(cmd, test.args, "")
+			if diff := cmp.Diff(suggestions, test.sugestions); diff != "" {
+				t.Errorf("SuggestWorkloadEnvNames() sugestions (-want, +got) = %v", diff)
+			}
+			if want, got := test.shellCompDirective, directive; want != got {
+				t.Errorf("SuggestWorkloadEnvNames() ShellCompDirective: want %d, got %d", want, got)
+			}
+		})
+	}
+}
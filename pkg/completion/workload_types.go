@@ -0,0 +1,61 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"context"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+)
+
+// DefaultWorkloadType is suggested when no supply chain selects workloads by type
+const DefaultWorkloadType = "web"
+
+// WorkloadTypes returns the sorted workload types selected by the cluster supply chains
+func WorkloadTypes(ctx context.Context, c *cli.Config) ([]string, error) {
+	supplyChains := &cartov1alpha1.ClusterSupplyChainList{}
+	if err := c.List(ctx, supplyChains); err != nil {
+		return nil, err
+	}
+	types := []string{}
+	seen := map[string]bool{}
+	for _, sc := range supplyChains.Items {
+		t, ok := sc.Spec.Selector[apis.WorkloadTypeLabelName]
+		if !ok || t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+func SuggestWorkloadTypes(ctx context.Context, c *cli.Config) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		types, err := WorkloadTypes(ctx, c)
+		if err != nil || len(types) == 0 {
+			return []string{DefaultWorkloadType}, cobra.ShellCompDirectiveNoFileComp
+		}
+		return types, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/apis"
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime"
+	clitesting "github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/testing"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/completion"
+)
+
+func TestSuggestWorkloadTypes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = cartov1alpha1.AddToScheme(scheme)
+
+	supplyChain := func(name string, selector map[string]string) *cartov1alpha1.ClusterSupplyChain {
+		return &cartov1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: cartov1alpha1.SupplyChainSpec{
+				Selector: selector,
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		given              []client.Object
+		reactor            clitesting.ReactionFunc
+		sugestions         []string
+		shellCompDirective cobra.ShellCompDirective
+	}{
+		{
+			name:               "no supply chains",
+			sugestions:         []string{"web"},
+			shellCompDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name: "supply chain types",
+			given: []client.Object{
+				supplyChain("source-to-url", map[string]string{apis.WorkloadTypeLabelName: "web"}),
+				supplyChain("source-to-api", map[string]string{apis.WorkloadTypeLabelName: "server"}),
+				supplyChain("source-test-to-url", map[string]string{apis.WorkloadTypeLabelName: "web", "apps.tanzu.vmware.com/has-tests": "true"}),
+				supplyChain("custom", map[string]string{"example.com/kind": "custom"}),
+			},
+			sugestions:         []string{"server", "web"},
+			shellCompDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			name:               "list error",
+			reactor:            clitesting.InduceFailure("list", "ClusterSupplyChainList"),
+			sugestions:         []string{"web"},
+			shellCompDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := cli.NewDefaultConfig("test", scheme)
+			client := clitesting.NewFakeClient(scheme, test.given...)
+			if test.reactor != nil {
+				client.AddReactor("*", "*", test.reactor)
+			}
+			c.Client = clitesting.NewFakeCliClient(client)
+			cmd := &cobra.Command{}
+
+			suggestions, directive := completion.SuggestWorkloadTypes(ctx, c)(cmd, []string{}, "")
+			if diff := cmp.Diff(suggestions, test.sugestions); diff != "" {
+				t.Errorf("SuggestWorkloadTypes() sugestions (-want, +got) = %v", diff)
+			}
+			if want, got := test.shellCompDirective, directive; want != got {
+				t.Errorf("SuggestWorkloadTypes() ShellCompDirective: want %d, got %d", want, got)
+			}
+		})
+	}
+}
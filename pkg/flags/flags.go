@@ -21,49 +21,85 @@ import (
 )
 
 const (
-	AllFlagName            = "--all"
-	AllNamespacesFlagName  = cli.AllNamespacesFlagName
-	AnnotationFlagName     = "--annotation"
-	AppFlagName            = "--app"
-	BuildEnvFlagName       = "--build-env"
-	ComponentFlagName      = "--component"
-	ConfigFlagName         = "--config"
-	ContextFlagName        = cli.ContextFlagName
-	DebugFlagName          = "--debug"
-	DryRunFlagName         = "--dry-run"
-	EnvFlagName            = "--env"
-	ExportFlagName         = "--export"
-	FilePathFlagName       = "--file"
-	GitBranchFlagName      = "--git-branch"
-	GitCommitFlagName      = "--git-commit"
-	GitFlagWildcard        = "--git-*"
-	GitRepoFlagName        = "--git-repo"
-	GitTagFlagName         = "--git-tag"
-	ImageFlagName          = "--image"
-	KubeConfigFlagName     = cli.KubeConfigFlagName
-	LabelFlagName          = "--label"
-	LimitCPUFlagName       = "--limit-cpu"
-	LimitMemoryFlagName    = "--limit-memory"
-	LiveUpdateFlagName     = "--live-update"
-	LocalPathFlagName      = "--local-path"
-	NamespaceFlagName      = cli.NamespaceFlagName
-	NoColorFlagName        = cli.NoColorFlagName
-	OutputFlagName         = "--output"
-	ParamFlagName          = "--param"
-	ParamYamlFlagName      = "--param-yaml"
-	RequestCPUFlagName     = "--request-cpu"
-	RequestMemoryFlagName  = "--request-memory"
-	ServiceAccountFlagName = "--service-account"
-	ServiceRefFlagName     = "--service-ref"
-	SinceFlagName          = "--since"
-	SourceImageFlagName    = "--source-image"
-	SubPathFlagName        = "--sub-path"
-	TailFlagName           = "--tail"
-	TimestampFlagName      = "--timestamp"
-	TailTimestampFlagName  = "--tail-timestamp"
-	TypeFlagName           = "--type"
-	VerboseLevelFlagName   = "--verbose"
-	WaitFlagName           = "--wait"
-	WaitTimeoutFlagName    = "--wait-timeout"
-	YesFlagName            = "--yes"
+	AllFlagName                   = "--all"
+	AllNamespacesFlagName         = cli.AllNamespacesFlagName
+	AnnotationFlagName            = "--annotation"
+	AppFlagName                   = "--app"
+	BuildFlagName                 = "--build"
+	BuildEnvFlagName              = "--build-env"
+	BuildEnvFileFlagName          = "--build-env-file"
+	BuildEnvFromConfigMapFlagName = "--build-env-from-configmap"
+	BuildEnvFromSecretFlagName    = "--build-env-from-secret"
+	ComponentFlagName             = "--component"
+	ConfigFlagName                = "--config"
+	ContainerFlagName             = "--container"
+	ContextFlagName               = cli.ContextFlagName
+	DebugFlagName                 = "--debug"
+	DetailsFlagName               = "--details"
+	DryRunFlagName                = "--dry-run"
+	EnvFlagName                   = "--env"
+	EnvFileFlagName               = "--env-file"
+	EnvFromConfigMapFlagName      = "--env-from-configmap"
+	EnvFromSecretFlagName         = "--env-from-secret"
+	ExcludeFlagName               = "--exclude"
+	ExportFlagName                = "--export"
+	ForceConflictsFlagName        = "--force-conflicts"
+	FilePathFlagName              = "--file"
+	FromFlagName                  = "--from"
+	FromConfigMapFlagName         = "--from-configmap"
+	FromSecretFlagName            = "--from-secret"
+	GitBranchFlagName             = "--git-branch"
+	GitCommitFlagName             = "--git-commit"
+	GitFlagWildcard               = "--git-*"
+	GitRepoFlagName               = "--git-repo"
+	GitTagFlagName                = "--git-tag"
+	ImageFlagName                 = "--image"
+	IncludeFlagName               = "--include"
+	InteractiveFlagName           = "--interactive"
+	KubeConfigFlagName            = cli.KubeConfigFlagName
+	LabelFlagName                 = "--label"
+	LimitCPUFlagName              = "--limit-cpu"
+	LimitMemoryFlagName           = "--limit-memory"
+	LiveUpdateFlagName            = "--live-update"
+	LocalPathFlagName             = "--local-path"
+	MaxLogRequestsFlagName        = "--max-log-requests"
+	NamespaceFlagName             = cli.NamespaceFlagName
+	NoColorFlagName               = cli.NoColorFlagName
+	NoConfigFlagName              = "--no-config"
+	OutputFlagName                = "--output"
+	OverlayFlagName               = "--overlay"
+	ParamFlagName                 = "--param"
+	ParamYamlFlagName             = "--param-yaml"
+	RegistryCACertFlagName        = "--registry-ca-cert"
+	RegistryConfigFlagName        = "--registry-config"
+	RegistryPasswordFlagName      = "--registry-password"
+	RegistryUsernameFlagName      = "--registry-username"
+	RegistryVerifyCertsFlagName   = "--registry-verify-certs"
+	RequestCPUFlagName            = "--request-cpu"
+	RequestMemoryFlagName         = "--request-memory"
+	ResourceFlagName              = "--resource"
+	RetryOnConflictFlagName       = "--retry-on-conflict"
+	ServerSideFlagName            = "--server-side"
+	ServiceAccountFlagName        = "--service-account"
+	ServiceRefFlagName            = "--service-ref"
+	ShowGraphFlagName             = "--show-graph"
+	SinceFlagName                 = "--since"
+	SourceImageFlagName           = "--source-image"
+	SourceOCILayoutFlagName       = "--source-oci-layout"
+	SourceSigningKeyFlagName      = "--source-signing-key"
+	SourceTarballFlagName         = "--source-tarball"
+	SubPathFlagName               = "--sub-path"
+	TailFlagName                  = "--tail"
+	TailLinesFlagName             = "--tail-lines"
+	TimestampFlagName             = "--timestamp"
+	TailOutputFlagName            = "--tail-output"
+	TailTimestampFlagName         = "--tail-timestamp"
+	TypeFlagName                  = "--type"
+	VarFlagName                   = "--var"
+	VarFileFlagName               = "--var-file"
+	VerboseLevelFlagName          = "--verbose"
+	WaitFlagName                  = "--wait"
+	WaitTimeoutFlagName           = "--wait-timeout"
+	WatchFlagName                 = "--watch"
+	YesFlagName                   = "--yes"
 )
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
+)
+
+func EventTablePrinter(w io.Writer, eventList *corev1.EventList) error {
+	return eventTablePrinter(w, eventList, table.PrintOptions{}, false)
+}
+
+// InvolvedObjectEventTablePrinter prints the events along with the object each event is about. The
+// headers are omitted when printing more events below a table that was already printed.
+func InvolvedObjectEventTablePrinter(w io.Writer, eventList *corev1.EventList, noHeaders bool) error {
+	return eventTablePrinter(w, eventList, table.PrintOptions{NoHeaders: noHeaders}, true)
+}
+
+func eventTablePrinter(w io.Writer, eventList *corev1.EventList, opts table.PrintOptions, involvedObject bool) error {
+	printEventRow := func(event *corev1.Event, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		row := metav1beta1.TableRow{
+			Object: runtime.RawExtension{Object: event},
+		}
+		row.Cells = append(row.Cells,
+			printer.TimestampSince(EventTimestamp(event), time.Now()),
+			event.Type,
+		)
+		if involvedObject {
+			row.Cells = append(row.Cells, fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name))
+		}
+		row.Cells = append(row.Cells,
+			event.Reason,
+			event.Message,
+		)
+		return []metav1beta1.TableRow{row}, nil
+	}
+	printEventList := func(events *corev1.EventList, printOpts table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		rows := make([]metav1beta1.TableRow, 0, len(events.Items))
+		for i := range events.Items {
+			r, err := printEventRow(&events.Items[i], printOpts)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, r...)
+		}
+		return rows, nil
+	}
+	tablePrinter := table.NewTablePrinter(opts).With(func(h table.PrintHandler) {
+		columns := []metav1beta1.TableColumnDefinition{
+			{Name: "Last Seen", Type: "string"},
+			{Name: "Type", Type: "string"},
+		}
+		if involvedObject {
+			columns = append(columns, metav1beta1.TableColumnDefinition{Name: "Object", Type: "string"})
+		}
+		columns = append(columns,
+			metav1beta1.TableColumnDefinition{Name: "Reason", Type: "string"},
+			metav1beta1.TableColumnDefinition{Name: "Message", Type: "string"},
+		)
+		h.TableHandler(columns, printEventList)
+		h.TableHandler(columns, printEventRow)
+	})
+	return tablePrinter.PrintObj(eventList, w)
+}
+
+// EventTimestamp is the last time the event was observed. Events recorded with the events.k8s.io
+// API only set the event time.
+func EventTimestamp(event *corev1.Event) metav1.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp
+	case !event.EventTime.IsZero():
+		return metav1.NewTime(event.EventTime.Time)
+	default:
+		return event.FirstTimestamp
+	}
+}
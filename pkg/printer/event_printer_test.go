@@ -0,0 +1,129 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestEventTablePrinter(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		events         *corev1.EventList
+		expectedOutput string
+	}{{
+		name:   "no events",
+		events: &corev1.EventList{},
+		expectedOutput: `
+LAST SEEN   TYPE   REASON   MESSAGE
+`,
+	}, {
+		name: "events",
+		events: &corev1.EventList{
+			Items: []corev1.Event{{
+				Type:           corev1.EventTypeNormal,
+				Reason:         "Created",
+				Message:        "created build my-workload-build-1",
+				FirstTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+				LastTimestamp:  metav1.NewTime(now.Add(-time.Hour)),
+			}, {
+				Type:      corev1.EventTypeWarning,
+				Reason:    "BuildFailed",
+				Message:   "build my-workload-build-1 failed",
+				EventTime: metav1.NewMicroTime(now.Add(-30 * time.Minute)),
+			}, {
+				Type:    corev1.EventTypeNormal,
+				Reason:  "Unknown",
+				Message: "event without timestamps",
+			}},
+		},
+		expectedOutput: `
+LAST SEEN   TYPE      REASON        MESSAGE
+60m         Normal    Created       created build my-workload-build-1
+30m         Warning   BuildFailed   build my-workload-build-1 failed
+<unknown>   Normal    Unknown       event without timestamps
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := printer.EventTablePrinter(output, test.events); err != nil {
+				t.Errorf("EventTablePrinter() expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), output.String()); diff != "" {
+				t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
+
+func TestInvolvedObjectEventTablePrinter(t *testing.T) {
+	events := &corev1.EventList{
+		Items: []corev1.Event{{
+			InvolvedObject: corev1.ObjectReference{
+				Kind: "Pod",
+				Name: "my-workload-pod",
+			},
+			Type:          corev1.EventTypeNormal,
+			Reason:        "Scheduled",
+			Message:       "assigned my-workload-pod to node",
+			LastTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}},
+	}
+
+	tests := []struct {
+		name           string
+		noHeaders      bool
+		expectedOutput string
+	}{{
+		name: "headers",
+		expectedOutput: `
+LAST SEEN   TYPE     OBJECT                REASON      MESSAGE
+60m         Normal   Pod/my-workload-pod   Scheduled   assigned my-workload-pod to node
+`,
+	}, {
+		name:      "no headers",
+		noHeaders: true,
+		expectedOutput: `
+60m   Normal   Pod/my-workload-pod   Scheduled   assigned my-workload-pod to node
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := printer.InvolvedObjectEventTablePrinter(output, events, test.noHeaders); err != nil {
+				t.Errorf("InvolvedObjectEventTablePrinter() expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), output.String()); diff != "" {
+				t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"io"
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
+)
+
+// conflictManagerRegex extracts the field manager from a server-side apply conflict message, for
+// example `conflict with "kapp" using carto.run/v1alpha1`
+var conflictManagerRegex = regexp.MustCompile(`^conflict with "([^"]*)"`)
+
+func WorkloadApplyConflictsPrinter(w io.Writer, workload *cartov1alpha1.Workload, conflicts []metav1.StatusCause) error {
+	printConflicts := func(workload *cartov1alpha1.Workload, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		rows := make([]metav1beta1.TableRow, 0, len(conflicts))
+		for _, conflict := range conflicts {
+			manager := conflict.Message
+			if match := conflictManagerRegex.FindStringSubmatch(conflict.Message); match != nil {
+				manager = match[1]
+			}
+			rows = append(rows, metav1beta1.TableRow{
+				Cells: []interface{}{
+					conflict.Field,
+					manager,
+				},
+			})
+		}
+		return rows, nil
+	}
+
+	tablePrinter := table.NewTablePrinter(table.PrintOptions{}).With(func(h table.PrintHandler) {
+		columns := []metav1beta1.TableColumnDefinition{
+			{Name: "Field", Type: "string"},
+			{Name: "Manager", Type: "string"},
+		}
+		h.TableHandler(columns, printConflicts)
+	})
+	return tablePrinter.PrintObj(workload, w)
+}
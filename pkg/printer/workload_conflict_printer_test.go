@@ -0,0 +1,89 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestWorkloadApplyConflictsPrinter(t *testing.T) {
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workload",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		conflicts      []metav1.StatusCause
+		expectedOutput string
+	}{{
+		name: "conflicts",
+		conflicts: []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "kapp" using carto.run/v1alpha1`,
+				Field:   ".spec.source.git.ref.branch",
+			},
+			{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "kubectl-edit" using carto.run/v1alpha1`,
+				Field:   `.spec.env[name="FOO"].value`,
+			},
+		},
+		expectedOutput: `
+FIELD                         MANAGER
+.spec.source.git.ref.branch   kapp
+.spec.env[name="FOO"].value   kubectl-edit
+`,
+	}, {
+		name: "unknown message format",
+		conflicts: []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: "conflicting manager",
+				Field:   ".metadata.labels.app",
+			},
+		},
+		expectedOutput: `
+FIELD                  MANAGER
+.metadata.labels.app   conflicting manager
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := printer.WorkloadApplyConflictsPrinter(output, workload, test.conflicts); err != nil {
+				t.Errorf("WorkloadApplyConflictsPrinter() expected no error, got %v", err)
+			}
+			outputString := output.String()
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), outputString); diff != "" {
+				t.Errorf("WorkloadApplyConflictsPrinter() (-expected, +actual) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
+)
+
+// notSet is shown for a variable that is not set at runtime or for the build
+const notSet = "-"
+
+// maskedValue replaces the value of environment variables set from a Secret
+const maskedValue = "********"
+
+// WorkloadEnvTablePrinter prints the runtime and build environment variables of a workload side
+// by side, one row per variable name. Runtime variables come first in the order of the workload
+// spec, followed by the variables only set for the build.
+func WorkloadEnvTablePrinter(w io.Writer, workload *cartov1alpha1.Workload) error {
+	printEnv := func(workload *cartov1alpha1.Workload, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		buildEnv := []corev1.EnvVar{}
+		if workload.Spec.Build != nil {
+			buildEnv = workload.Spec.Build.Env
+		}
+
+		names := []string{}
+		runtime := map[string]corev1.EnvVar{}
+		build := map[string]corev1.EnvVar{}
+		for _, env := range workload.Spec.Env {
+			if _, ok := runtime[env.Name]; !ok {
+				names = append(names, env.Name)
+			}
+			runtime[env.Name] = env
+		}
+		for _, env := range buildEnv {
+			if _, ok := runtime[env.Name]; !ok {
+				if _, ok := build[env.Name]; !ok {
+					names = append(names, env.Name)
+				}
+			}
+			build[env.Name] = env
+		}
+
+		rows := make([]metav1beta1.TableRow, 0, len(names))
+		for _, name := range names {
+			row := metav1beta1.TableRow{
+				Cells: []interface{}{name, notSet, notSet},
+			}
+			if env, ok := runtime[name]; ok {
+				row.Cells[1] = envVarValue(env)
+			}
+			if env, ok := build[name]; ok {
+				row.Cells[2] = envVarValue(env)
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+
+	tablePrinter := table.NewTablePrinter(table.PrintOptions{}).With(func(h table.PrintHandler) {
+		columns := []metav1beta1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Runtime", Type: "string"},
+			{Name: "Build", Type: "string"},
+		}
+		h.TableHandler(columns, printEnv)
+	})
+	return tablePrinter.PrintObj(workload, w)
+}
+
+// envVarValue describes the value of an environment variable, values from a Secret are masked and
+// only the Secret key is shown
+func envVarValue(env corev1.EnvVar) string {
+	if env.ValueFrom == nil {
+		return env.Value
+	}
+	switch from := env.ValueFrom; {
+	case from.SecretKeyRef != nil:
+		return fmt.Sprintf("%s (secret %s:%s)", maskedValue, from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+	case from.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("(configmap %s:%s)", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+	case from.FieldRef != nil:
+		return fmt.Sprintf("(field %s)", from.FieldRef.FieldPath)
+	case from.ResourceFieldRef != nil:
+		return fmt.Sprintf("(resource %s)", from.ResourceFieldRef.Resource)
+	default:
+		return "<unknown>"
+	}
+}
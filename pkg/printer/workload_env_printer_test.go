@@ -0,0 +1,122 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestWorkloadEnvTablePrinter(t *testing.T) {
+	tests := []struct {
+		name           string
+		workload       *cartov1alpha1.Workload
+		expectedOutput string
+	}{{
+		name: "runtime env",
+		workload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-workload"},
+			Spec: cartov1alpha1.WorkloadSpec{
+				Env: []corev1.EnvVar{
+					{Name: "FOO", Value: "foo"},
+					{Name: "BAR", Value: "bar"},
+				},
+			},
+		},
+		expectedOutput: `
+NAME   RUNTIME   BUILD
+FOO    foo       -
+BAR    bar       -
+`,
+	}, {
+		name: "runtime and build env",
+		workload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-workload"},
+			Spec: cartov1alpha1.WorkloadSpec{
+				Env: []corev1.EnvVar{
+					{Name: "LOG_LEVEL", Value: "info"},
+				},
+				Build: &cartov1alpha1.WorkloadBuild{
+					Env: []corev1.EnvVar{
+						{Name: "BP_JVM_VERSION", Value: "17"},
+						{Name: "LOG_LEVEL", Value: "debug"},
+					},
+				},
+			},
+		},
+		expectedOutput: `
+NAME             RUNTIME   BUILD
+LOG_LEVEL        info      debug
+BP_JVM_VERSION   -         17
+`,
+	}, {
+		name: "env from references",
+		workload: &cartov1alpha1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-workload"},
+			Spec: cartov1alpha1.WorkloadSpec{
+				Env: []corev1.EnvVar{
+					{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+							Key:                  "password",
+						},
+					}},
+					{Name: "CONFIG", ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+							Key:                  "config",
+						},
+					}},
+					{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+					}},
+					{Name: "CPU", ValueFrom: &corev1.EnvVarSource{
+						ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.cpu"},
+					}},
+				},
+			},
+		},
+		expectedOutput: `
+NAME       RUNTIME                                BUILD
+PASSWORD   ******** (secret my-secret:password)   -
+CONFIG     (configmap my-config:config)           -
+POD_NAME   (field metadata.name)                  -
+CPU        (resource limits.cpu)                  -
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := printer.WorkloadEnvTablePrinter(output, test.workload); err != nil {
+				t.Errorf("WorkloadEnvTablePrinter() unexpected error = %v", err)
+			}
+			expected := strings.TrimPrefix(test.expectedOutput, "\n")
+			if diff := cmp.Diff(expected, output.String()); diff != "" {
+				t.Errorf("WorkloadEnvTablePrinter() (-expected, +actual) = %s", diff)
+			}
+		})
+	}
+}
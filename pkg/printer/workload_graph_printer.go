@@ -0,0 +1,276 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+)
+
+const (
+	OutputFormatDot     = "dot"
+	OutputFormatMermaid = "mermaid"
+)
+
+// maxPreviewLength is the number of characters of an output preview shown in a graph node
+const maxPreviewLength = 60
+
+// resourceGraph is the realized supply chain of a workload, each resource is fed by the resources
+// named in its inputs
+type resourceGraph struct {
+	resources []cartov1alpha1.RealizedResource
+	index     map[string]int
+}
+
+func newResourceGraph(workload *cartov1alpha1.Workload) *resourceGraph {
+	g := &resourceGraph{
+		resources: workload.Status.Resources,
+		index:     map[string]int{},
+	}
+	for i, r := range g.resources {
+		g.index[r.Name] = i
+	}
+	return g
+}
+
+// roots are the resources not fed by any other resource of the supply chain
+func (g *resourceGraph) roots() []int {
+	roots := []int{}
+	for i, r := range g.resources {
+		fed := false
+		for _, input := range r.Inputs {
+			if _, ok := g.index[input.Name]; ok {
+				fed = true
+				break
+			}
+		}
+		if !fed {
+			roots = append(roots, i)
+		}
+	}
+	return roots
+}
+
+// consumers are the resources that have the resource as an input
+func (g *resourceGraph) consumers(name string) []int {
+	consumers := []int{}
+	for i, r := range g.resources {
+		for _, input := range r.Inputs {
+			if input.Name == name {
+				consumers = append(consumers, i)
+				break
+			}
+		}
+	}
+	return consumers
+}
+
+// WorkloadResourcesGraphPrinter prints the realized supply chain as a tree, starting from the
+// resources without inputs. A resource fed by several resources is shown in full the first time
+// it is reached.
+func WorkloadResourcesGraphPrinter(w io.Writer, workload *cartov1alpha1.Workload) error {
+	g := newResourceGraph(workload)
+	printed := make([]bool, len(g.resources))
+
+	var printResource func(i int, prefix, connector, indent string) error
+	printResource = func(i int, prefix, connector, indent string) error {
+		r := g.resources[i]
+		if printed[i] {
+			_, err := fmt.Fprintf(w, "%s%s%s (see above)\n", prefix, connector, r.Name)
+			return err
+		}
+		printed[i] = true
+		if _, err := fmt.Fprintf(w, "%s%s%s\n", prefix, connector, resourceSummary(r)); err != nil {
+			return err
+		}
+
+		childPrefix := prefix + indent
+		consumers := g.consumers(r.Name)
+		outputIndent := "  "
+		if len(consumers) > 0 {
+			outputIndent = "│ "
+		}
+		for _, output := range r.Outputs {
+			if _, err := fmt.Fprintf(w, "%s%s%s\n", childPrefix, outputIndent, outputSummary(output)); err != nil {
+				return err
+			}
+		}
+		for j, consumer := range consumers {
+			connector, indent := "├── ", "│   "
+			if j == len(consumers)-1 {
+				connector, indent = "└── ", "    "
+			}
+			if err := printResource(consumer, childPrefix, connector, indent); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, root := range g.roots() {
+		if err := printResource(root, "", "", ""); err != nil {
+			return err
+		}
+	}
+	// resources in a cycle are never reached from a root
+	for i := range g.resources {
+		if !printed[i] {
+			if err := printResource(i, "", "", ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WorkloadResourcesDotPrinter prints the realized supply chain as a Graphviz digraph
+func WorkloadResourcesDotPrinter(w io.Writer, workload *cartov1alpha1.Workload) error {
+	g := newResourceGraph(workload)
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(workload.Name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, r := range g.resources {
+		lines := []string{resourceSummary(r)}
+		for _, output := range r.Outputs {
+			lines = append(lines, outputSummary(output))
+		}
+		label := make([]string, len(lines))
+		for i, line := range lines {
+			label[i] = dotEscape(line)
+		}
+		fmt.Fprintf(b, "  %s [label=\"%s\", color=%s];\n", dotQuote(r.Name), strings.Join(label, "\\n"), readinessColor(r))
+	}
+	for _, r := range g.resources {
+		for _, input := range r.Inputs {
+			fmt.Fprintf(b, "  %s -> %s;\n", dotQuote(input.Name), dotQuote(r.Name))
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WorkloadResourcesMermaidPrinter prints the realized supply chain as a Mermaid flowchart
+func WorkloadResourcesMermaidPrinter(w io.Writer, workload *cartov1alpha1.Workload) error {
+	g := newResourceGraph(workload)
+	b := &strings.Builder{}
+
+	// resource names are not valid mermaid ids, nodes are numbered instead
+	ids := map[string]string{}
+	id := func(name string) string {
+		if _, ok := ids[name]; !ok {
+			ids[name] = fmt.Sprintf("r%d", len(ids))
+		}
+		return ids[name]
+	}
+
+	b.WriteString("flowchart LR\n")
+	for _, r := range g.resources {
+		lines := []string{mermaidEscape(resourceSummary(r))}
+		for _, output := range r.Outputs {
+			lines = append(lines, mermaidEscape(outputSummary(output)))
+		}
+		fmt.Fprintf(b, "  %s[\"%s\"]:::%s\n", id(r.Name), strings.Join(lines, "<br/>"), readinessClass(r))
+	}
+	for _, r := range g.resources {
+		for _, input := range r.Inputs {
+			if _, ok := g.index[input.Name]; !ok {
+				fmt.Fprintf(b, "  %s[\"%s\"]\n", id(input.Name), mermaidEscape(input.Name))
+			}
+			fmt.Fprintf(b, "  %s --> %s\n", id(input.Name), id(r.Name))
+		}
+	}
+	b.WriteString("  classDef ready stroke:green\n")
+	b.WriteString("  classDef failed stroke:red\n")
+	b.WriteString("  classDef unknown stroke:orange\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func resourceSummary(r cartov1alpha1.RealizedResource) string {
+	ready, elapsedTransitionTime := findConditionReady(r.Conditions, cartov1alpha1.ConditionResourceReady)
+	if ready == "" {
+		return r.Name
+	}
+	return fmt.Sprintf("%s (%s, %s)", r.Name, ready, elapsedTransitionTime)
+}
+
+func outputSummary(output cartov1alpha1.Output) string {
+	preview := strings.TrimSpace(output.Preview)
+	if i := strings.Index(preview, "\n"); i != -1 {
+		preview = strings.TrimSpace(preview[:i]) + "..."
+	}
+	if runes := []rune(preview); len(runes) > maxPreviewLength {
+		preview = string(runes[:maxPreviewLength]) + "..."
+	}
+	summary := fmt.Sprintf("%s: %s", output.Name, preview)
+	if output.Digest != "" {
+		summary = fmt.Sprintf("%s (%s)", summary, shortDigest(output.Digest))
+	}
+	return summary
+}
+
+// shortDigest abbreviates a sha256 digest to 12 hex characters
+func shortDigest(digest string) string {
+	const prefix = "sha256:"
+	if strings.HasPrefix(digest, prefix) && len(digest) > len(prefix)+12 {
+		return digest[:len(prefix)+12]
+	}
+	return digest
+}
+
+func readinessClass(r cartov1alpha1.RealizedResource) string {
+	ready, _ := findConditionReady(r.Conditions, cartov1alpha1.ConditionResourceReady)
+	switch ready {
+	case "True":
+		return "ready"
+	case "False":
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+func readinessColor(r cartov1alpha1.RealizedResource) string {
+	switch readinessClass(r) {
+	case "ready":
+		return "green"
+	case "failed":
+		return "red"
+	default:
+		return "orange"
+	}
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func dotQuote(s string) string {
+	return fmt.Sprintf("\"%s\"", dotEscape(s))
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func realizedSupplyChain() *cartov1alpha1.Workload {
+	ready := func(status metav1.ConditionStatus) []metav1.Condition {
+		return []metav1.Condition{{Type: cartov1alpha1.ConditionResourceReady, Status: status}}
+	}
+	return &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workload",
+			Namespace: "default",
+		},
+		Status: cartov1alpha1.WorkloadStatus{
+			Resources: []cartov1alpha1.RealizedResource{{
+				Name: "source-provider",
+				Outputs: []cartov1alpha1.Output{{
+					Name:    "url",
+					Preview: "http://source-controller.flux-system.svc.cluster.local./gitrepository/default/my-workload/abc123.tar.gz\n",
+					Digest:  "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				}, {
+					Name:    "revision",
+					Preview: "main/abc123\n",
+					Digest:  "sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210",
+				}},
+				Conditions: ready(metav1.ConditionTrue),
+			}, {
+				Name:       "deliverable",
+				Conditions: ready(metav1.ConditionUnknown),
+			}, {
+				Name:   "image-builder",
+				Inputs: []cartov1alpha1.Input{{Name: "source-provider"}},
+				Outputs: []cartov1alpha1.Output{{
+					Name:    "image",
+					Preview: "registry.example.com/my-workload@sha256:abc\n",
+				}},
+				Conditions: ready(metav1.ConditionTrue),
+			}, {
+				Name:       "config-provider",
+				Inputs:     []cartov1alpha1.Input{{Name: "image-builder"}},
+				Conditions: ready(metav1.ConditionFalse),
+			}, {
+				Name:   "api-descriptors",
+				Inputs: []cartov1alpha1.Input{{Name: "image-builder"}, {Name: "config-provider"}},
+			}},
+		},
+	}
+}
+
+func TestWorkloadResourcesGraphPrinters(t *testing.T) {
+	tests := []struct {
+		name           string
+		printer        func(io.Writer, *cartov1alpha1.Workload) error
+		testWorkload   *cartov1alpha1.Workload
+		expectedOutput string
+	}{{
+		name:         "tree",
+		printer:      printer.WorkloadResourcesGraphPrinter,
+		testWorkload: realizedSupplyChain(),
+		expectedOutput: `
+source-provider (True, <unknown>)
+│ url: http://source-controller.flux-system.svc.cluster.local./gitr... (sha256:0123456789ab)
+│ revision: main/abc123 (sha256:fedcba987654)
+└── image-builder (True, <unknown>)
+    │ image: registry.example.com/my-workload@sha256:abc
+    ├── config-provider (False, <unknown>)
+    │   └── api-descriptors
+    └── api-descriptors (see above)
+deliverable (Unknown, <unknown>)
+`,
+	}, {
+		name:    "tree with cycle",
+		printer: printer.WorkloadResourcesGraphPrinter,
+		testWorkload: &cartov1alpha1.Workload{
+			Status: cartov1alpha1.WorkloadStatus{
+				Resources: []cartov1alpha1.RealizedResource{{
+					Name:   "first",
+					Inputs: []cartov1alpha1.Input{{Name: "second"}},
+				}, {
+					Name:   "second",
+					Inputs: []cartov1alpha1.Input{{Name: "first"}},
+				}},
+			},
+		},
+		expectedOutput: `
+first
+└── second
+    └── first (see above)
+`,
+	}, {
+		name:         "dot",
+		printer:      printer.WorkloadResourcesDotPrinter,
+		testWorkload: realizedSupplyChain(),
+		expectedOutput: `
+digraph "my-workload" {
+  rankdir=LR;
+  node [shape=box];
+  "source-provider" [label="source-provider (True, <unknown>)\nurl: http://source-controller.flux-system.svc.cluster.local./gitr... (sha256:0123456789ab)\nrevision: main/abc123 (sha256:fedcba987654)", color=green];
+  "deliverable" [label="deliverable (Unknown, <unknown>)", color=orange];
+  "image-builder" [label="image-builder (True, <unknown>)\nimage: registry.example.com/my-workload@sha256:abc", color=green];
+  "config-provider" [label="config-provider (False, <unknown>)", color=red];
+  "api-descriptors" [label="api-descriptors", color=orange];
+  "source-provider" -> "image-builder";
+  "image-builder" -> "config-provider";
+  "image-builder" -> "api-descriptors";
+  "config-provider" -> "api-descriptors";
+}
+`,
+	}, {
+		name:         "mermaid",
+		printer:      printer.WorkloadResourcesMermaidPrinter,
+		testWorkload: realizedSupplyChain(),
+		expectedOutput: `
+flowchart LR
+  r0["source-provider (True, #lt;unknown#gt;)<br/>url: http://source-controller.flux-system.svc.cluster.local./gitr... (sha256:0123456789ab)<br/>revision: main/abc123 (sha256:fedcba987654)"]:::ready
+  r1["deliverable (Unknown, #lt;unknown#gt;)"]:::unknown
+  r2["image-builder (True, #lt;unknown#gt;)<br/>image: registry.example.com/my-workload@sha256:abc"]:::ready
+  r3["config-provider (False, #lt;unknown#gt;)"]:::failed
+  r4["api-descriptors"]:::unknown
+  r0 --> r2
+  r2 --> r3
+  r2 --> r4
+  r3 --> r4
+  classDef ready stroke:green
+  classDef failed stroke:red
+  classDef unknown stroke:orange
+`,
+	}, {
+		name:    "mermaid with unknown input",
+		printer: printer.WorkloadResourcesMermaidPrinter,
+		testWorkload: &cartov1alpha1.Workload{
+			Status: cartov1alpha1.WorkloadStatus{
+				Resources: []cartov1alpha1.RealizedResource{{
+					Name:   "image-builder",
+					Inputs: []cartov1alpha1.Input{{Name: "source-provider"}},
+				}},
+			},
+		},
+		expectedOutput: `
+flowchart LR
+  r0["image-builder"]:::unknown
+  r1["source-provider"]
+  r1 --> r0
+  classDef ready stroke:green
+  classDef failed stroke:red
+  classDef unknown stroke:orange
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := test.printer(output, test.testWorkload); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			outputString := output.String()
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), outputString); diff != "" {
+				t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/cli-runtime/printer/table"
+)
+
+// stampedObjectConditionTypes are the condition types that report whether a stamped object is
+// ready, in order of preference. Tekton style objects report Succeeded instead of Ready.
+var stampedObjectConditionTypes = []string{"Ready", "Succeeded"}
+
+// WorkloadResourceDetailsPrinter prints the template and the object stamped for a supply chain
+// resource along with the ready condition of the stamped object. The stamped object is nil when it
+// could not be fetched.
+func WorkloadResourceDetailsPrinter(w io.Writer, workload *cartov1alpha1.Workload, resource *cartov1alpha1.RealizedResource, stamped *unstructured.Unstructured) error {
+	printDetails := func(workload *cartov1alpha1.Workload, _ table.PrintOptions) ([]metav1beta1.TableRow, error) {
+		rows := []metav1beta1.TableRow{}
+		if resource.TemplateRef != nil {
+			rows = append(rows, metav1beta1.TableRow{
+				Cells: []interface{}{"template:", fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, resource.TemplateRef.Name)},
+			})
+		}
+		if resource.StampedRef != nil {
+			gvk := schema.FromAPIVersionAndKind(resource.StampedRef.APIVersion, resource.StampedRef.Kind)
+			rows = append(rows, metav1beta1.TableRow{
+				Cells: []interface{}{"object:", fmt.Sprintf("%s/%s", gvk.GroupKind(), resource.StampedRef.Name)},
+			})
+		}
+		if stamped == nil {
+			return rows, nil
+		}
+
+		status, reason, message := "<unknown>", "", ""
+		if cond := findUnstructuredCondition(stamped, stampedObjectConditionTypes...); cond != nil {
+			status, _, _ = unstructured.NestedString(cond, "status")
+			reason, _, _ = unstructured.NestedString(cond, "reason")
+			message, _, _ = unstructured.NestedString(cond, "message")
+		}
+		rows = append(rows, metav1beta1.TableRow{
+			Cells: []interface{}{"ready:", status},
+		})
+		if reason != "" {
+			rows = append(rows, metav1beta1.TableRow{
+				Cells: []interface{}{"reason:", reason},
+			})
+		}
+		if message != "" {
+			rows = append(rows, metav1beta1.TableRow{
+				Cells: []interface{}{"message:", strings.TrimSpace(message)},
+			})
+		}
+		return rows, nil
+	}
+
+	tablePrinter := table.NewTablePrinter(table.PrintOptions{NoHeaders: true}).With(func(h table.PrintHandler) {
+		h.TableHandler(nil, printDetails)
+	})
+	return tablePrinter.PrintObj(workload, w)
+}
+
+// findUnstructuredCondition returns the first condition of the object's status matching the
+// condition types in order
+func findUnstructuredCondition(obj *unstructured.Unstructured, conditionTypes ...string) map[string]interface{} {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, conditionType := range conditionTypes {
+		for _, c := range conditions {
+			cond, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if t, _, _ := unstructured.NestedString(cond, "type"); t == conditionType {
+				return cond
+			}
+		}
+	}
+	return nil
+}
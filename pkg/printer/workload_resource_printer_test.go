@@ -0,0 +1,143 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cartov1alpha1 "github.com/vmware-tanzu/apps-cli-plugin/pkg/apis/cartographer/v1alpha1"
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/printer"
+)
+
+func TestWorkloadResourceDetailsPrinter(t *testing.T) {
+	workload := &cartov1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workload",
+			Namespace: "default",
+		},
+	}
+	resource := &cartov1alpha1.RealizedResource{
+		Name: "image-builder",
+		TemplateRef: &corev1.ObjectReference{
+			APIVersion: "carto.run/v1alpha1",
+			Kind:       "ClusterImageTemplate",
+			Name:       "kpack-template",
+		},
+		StampedRef: &corev1.ObjectReference{
+			APIVersion: "kpack.io/v1alpha2",
+			Kind:       "Image",
+			Name:       "my-workload",
+		},
+	}
+	stamped := func(conditions ...interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "kpack.io/v1alpha2",
+				"kind":       "Image",
+				"status": map[string]interface{}{
+					"conditions": conditions,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		resource       *cartov1alpha1.RealizedResource
+		stamped        *unstructured.Unstructured
+		expectedOutput string
+	}{{
+		name:     "not ready",
+		resource: resource,
+		stamped: stamped(map[string]interface{}{
+			"type":    "Ready",
+			"status":  "False",
+			"reason":  "BuildFailed",
+			"message": "build failed\n",
+		}),
+		expectedOutput: `
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+ready:      False
+reason:     BuildFailed
+message:    build failed
+`,
+	}, {
+		name:     "succeeded condition",
+		resource: resource,
+		stamped: stamped(map[string]interface{}{
+			"type":   "Succeeded",
+			"status": "True",
+		}),
+		expectedOutput: `
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+ready:      True
+`,
+	}, {
+		name:     "no ready condition",
+		resource: resource,
+		stamped: stamped(map[string]interface{}{
+			"type":   "BuilderReady",
+			"status": "True",
+		}),
+		expectedOutput: `
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+ready:      <unknown>
+`,
+	}, {
+		name:     "stamped object not available",
+		resource: resource,
+		expectedOutput: `
+template:   ClusterImageTemplate/kpack-template
+object:     Image.kpack.io/my-workload
+`,
+	}, {
+		name: "core object without template",
+		resource: &cartov1alpha1.RealizedResource{
+			Name: "config-writer",
+			StampedRef: &corev1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       "my-workload",
+			},
+		},
+		expectedOutput: `
+object:   ConfigMap/my-workload
+`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			if err := printer.WorkloadResourceDetailsPrinter(output, workload, test.resource, test.stamped); err != nil {
+				t.Errorf("WorkloadResourceDetailsPrinter() expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(strings.TrimPrefix(test.expectedOutput, "\n"), output.String()); diff != "" {
+				t.Errorf("Unexpected output (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
@@ -17,13 +17,51 @@ limitations under the License.
 package source
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
+// JarExt is the extension of java archives, which are zip files
+const JarExt = ".jar"
+
+// ExtractArchive extracts contents of fileName zip, jar, tar or gzipped tar file to dir
+func ExtractArchive(dir, fileName string) error {
+	switch {
+	case IsZip(fileName):
+		return ExtractZip(dir, fileName)
+	case strings.EqualFold(filepath.Ext(fileName), JarExt):
+		return fmt.Errorf("%q is not a valid jar file", fileName)
+	case IsTarGz(fileName):
+		return ExtractTarGz(dir, fileName)
+	case IsTar(fileName):
+		return ExtractTar(dir, fileName)
+	}
+	return fmt.Errorf("unsupported file format %q", fileName)
+}
+
+// IsArchive checks if fileName is a file format supported by ExtractArchive
+func IsArchive(fileName string) bool {
+	return IsZip(fileName) || strings.EqualFold(filepath.Ext(fileName), JarExt) || IsTarGz(fileName) || IsTar(fileName)
+}
+
+// archivePath resolves the path of an archive entry in dir, rejecting entries that would be
+// written outside of dir
+func archivePath(dir, name string) (string, error) {
+	filePath := filepath.Join(dir, name)
+	if rel, err := filepath.Rel(dir, filePath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("illegal file path %q in archive", name)
+	}
+	return filePath, nil
+}
+
 // ExtractZip extracts contents of fileName zip file to dir
 // Returns error if there is any error reading from zip file into dir
 func ExtractZip(dir, fileName string) error {
@@ -42,7 +80,10 @@ func ExtractZip(dir, fileName string) error {
 	}
 
 	for _, file := range zipReader.File {
-		filePath := filepath.Join(dir, file.Name)
+		filePath, err := archivePath(dir, file.Name)
+		if err != nil {
+			return err
+		}
 		fileMode := file.Mode()
 		if isFatFile(file.FileHeader) {
 			fileMode = 0777
@@ -85,6 +126,120 @@ func ExtractZip(dir, fileName string) error {
 	return nil
 }
 
+// ExtractTar extracts contents of fileName tar file to dir
+func ExtractTar(dir, fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return extractTar(dir, file)
+}
+
+// ExtractTarGz extracts contents of fileName gzipped tar file to dir
+func ExtractTarGz(dir, fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	return extractTar(dir, gzipReader)
+}
+
+func extractTar(dir string, reader io.Reader) error {
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		filePath, err := archivePath(dir, header.Name)
+		if err != nil {
+			return err
+		}
+		fileMode := header.FileInfo().Mode().Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(filePath, fileMode|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+				return err
+			}
+
+			outFile, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode)
+			if err != nil {
+				return err
+			}
+
+			if _, err := io.Copy(outFile, tarReader); err != nil {
+				outFile.Close()
+				return err
+			}
+
+			if err := outFile.Close(); err != nil {
+				return err
+			}
+		case tar.TypeXGlobalHeader:
+			// pax metadata, not a file
+		default:
+			// links and devices can not be published as source
+			return fmt.Errorf("unsupported file type for %q in archive", header.Name)
+		}
+	}
+}
+
+// IsTar checks for the ustar magic of tar files
+func IsTar(fileName string) bool {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	return isTarHeader(file)
+}
+
+// IsTarGz checks for a gzip compressed tar file
+func IsTarGz(fileName string) bool {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return false
+	}
+	defer gzipReader.Close()
+
+	return isTarHeader(gzipReader)
+}
+
+func isTarHeader(reader io.Reader) bool {
+	// https://www.gnu.org/software/tar/manual/html_node/Standard.html
+	buf := make([]byte, 512)
+	if _, err := io.ReadFull(reader, buf); err != nil {
+		return false
+	}
+	return bytes.HasPrefix(buf[257:], []byte("ustar"))
+}
+
 func IsZip(fileName string) bool {
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -120,6 +120,10 @@ func TestHandleZip(t *testing.T) {
 		name:      "invalid jar",
 		file:      "testdata/invalid.jar",
 		shouldErr: true,
+	}, {
+		name:      "path traversal",
+		file:      "testdata/traversal.zip",
+		shouldErr: true,
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -162,3 +166,132 @@ func TestHandleZip(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTar(t *testing.T) {
+	tests := []struct {
+		name          string
+		fileName      string
+		expectedTar   bool
+		expectedTarGz bool
+	}{{
+		name:        "tar",
+		fileName:    "testdata/hello.go.tar",
+		expectedTar: true,
+	}, {
+		name:          "gzipped tar",
+		fileName:      "testdata/hello.go.tgz",
+		expectedTarGz: true,
+	}, {
+		name:     "zip",
+		fileName: "testdata/hello.go.zip",
+	}, {
+		name:     "dir",
+		fileName: "testdata/hello_tar",
+	}, {
+		name:     "non existing file",
+		fileName: "testdata/non_file",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := IsTar(test.fileName); actual != test.expectedTar {
+				t.Errorf("IsTar() expected %v actual %v", test.expectedTar, actual)
+			}
+			if actual := IsTarGz(test.fileName); actual != test.expectedTarGz {
+				t.Errorf("IsTarGz() expected %v actual %v", test.expectedTarGz, actual)
+			}
+		})
+	}
+}
+
+func TestExtractArchive(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		want        string
+		expectedErr string
+	}{{
+		name: "zip",
+		file: "testdata/hello.go.zip",
+		want: "testdata/hello_zip",
+	}, {
+		name: "jar",
+		file: "testdata/hello.go.jar",
+		want: "testdata/hello_jar",
+	}, {
+		name: "tar",
+		file: "testdata/hello.go.tar",
+		want: "testdata/hello_tar",
+	}, {
+		name: "gzipped tar",
+		file: "testdata/hello.go.tgz",
+		want: "testdata/hello_tar",
+	}, {
+		name:        "invalid jar",
+		file:        "testdata/invalid.jar",
+		expectedErr: `"testdata/invalid.jar" is not a valid jar file`,
+	}, {
+		name:        "unsupported file",
+		file:        "testdata/hello_zip/hello.go",
+		expectedErr: `unsupported file format "testdata/hello_zip/hello.go"`,
+	}, {
+		name:        "tar path traversal",
+		file:        "testdata/traversal.tar",
+		expectedErr: `illegal file path "../evil.go" in archive`,
+	}, {
+		name:        "zip path traversal",
+		file:        "testdata/traversal.zip",
+		expectedErr: `illegal file path "../evil.go" in archive`,
+	}, {
+		name:        "tar symlink",
+		file:        "testdata/symlink.tar",
+		expectedErr: `unsupported file type for "passwd" in archive`,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := ExtractArchive(filepath.Join(dir, "out"), test.file)
+			if test.expectedErr != "" {
+				if err == nil || err.Error() != test.expectedErr {
+					t.Errorf("ExtractArchive() expected error %q, actual %v", test.expectedErr, err)
+				}
+				if _, err := os.Stat(filepath.Join(dir, "evil.go")); err == nil {
+					t.Errorf("ExtractArchive() wrote outside of the target dir")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtractArchive() errored %v", err)
+			}
+			if diff := cmp.Diff(readTree(t, test.want), readTree(t, filepath.Join(dir, "out"))); diff != "" {
+				t.Errorf("ExtractArchive() (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+// readTree maps the relative path of each file in dir to its content, skipping mac metadata
+func readTree(t *testing.T, dir string) map[string]string {
+	tree := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == "__MACOSX" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		tree[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read %q: %v", dir, err)
+	}
+	return tree
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// IgnoreFileName lists the local source paths excluded from uploads, in gitignore syntax
+	IgnoreFileName = ".tanzuignore"
+	// GitIgnoreFileName is used when there is no IgnoreFileName
+	GitIgnoreFileName = ".gitignore"
+)
+
+// alwaysExcluded are never part of the uploaded source, git metadata included
+var alwaysExcluded = []string{".git", ".imgpkg"}
+
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Ignore matches paths against the rules of an ignore file, the last matching rule wins
+type Ignore struct {
+	rules []ignoreRule
+}
+
+// ParseIgnore reads the rules of an ignore file, in gitignore syntax. Like git, lines that are not
+// valid patterns are skipped.
+func ParseIgnore(content string) *Ignore {
+	ignore := &Ignore{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		// patterns without a slash match at any depth, others are relative to the ignore file
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		expr := globToRegexp(line)
+		if !anchored {
+			expr = "(.*/)?" + expr
+		}
+		pattern, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			continue
+		}
+		rule.pattern = pattern
+		ignore.rules = append(ignore.rules, rule)
+	}
+	return ignore
+}
+
+// globToRegexp converts a gitignore glob to a regular expression, ** matches any number of
+// directories
+func globToRegexp(glob string) string {
+	b := &strings.Builder{}
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			class, n := bracketToRegexp(glob[i:])
+			if n == 0 {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			b.WriteString(class)
+			i += n - 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// bracketToRegexp converts the bracket expression at the start of the glob to a regular expression
+// character class, returning the length of the expression in the glob. The length is zero when the
+// bracket is not closed, the bracket is then matched literally. A ] right after the opening bracket
+// is part of the class, and POSIX classes like [:alpha:] are kept as is.
+func bracketToRegexp(glob string) (string, int) {
+	b := &strings.Builder{}
+	b.WriteString("[")
+	i := 1
+	if i < len(glob) && (glob[i] == '!' || glob[i] == '^') {
+		b.WriteString("^")
+		i++
+	}
+	for first := true; i < len(glob); first = false {
+		c := glob[i]
+		switch {
+		case c == ']' && !first:
+			b.WriteString("]")
+			return b.String(), i + 1
+		case strings.HasPrefix(glob[i:], "[:"):
+			end := strings.Index(glob[i+2:], ":]")
+			if end == -1 {
+				b.WriteString(`\[`)
+				i++
+				continue
+			}
+			b.WriteString(glob[i : i+2+end+2])
+			i += 2 + end + 2
+		case c == '\\' && i+1 < len(glob):
+			b.WriteString(classChar(glob[i+1]))
+			i += 2
+		case c == '\\' || c == '[' || c == ']' || c == '^':
+			b.WriteString(classChar(c))
+			i++
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0
+}
+
+// classChar matches the character literally inside a regular expression character class
+func classChar(c byte) string {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80 {
+		return string(c)
+	}
+	return `\` + string(c)
+}
+
+// Match checks if the slash separated path, relative to the ignore file, is ignored
+func (i *Ignore) Match(path string, isDir bool) bool {
+	ignored := false
+	for _, rule := range i.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.pattern.MatchString(path) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// LoadIgnore reads the ignore file of the directory, falling back to the gitignore file. When the
+// directory has neither, nothing is ignored.
+func LoadIgnore(dir string) (*Ignore, error) {
+	for _, name := range []string{IgnoreFileName, GitIgnoreFileName} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %q: %w", filepath.Join(dir, name), err)
+		}
+		return ParseIgnore(string(content)), nil
+	}
+	return &Ignore{}, nil
+}
+
+// SourceFile is a file of the local source to upload
+type SourceFile struct {
+	Path string
+	Size int64
+}
+
+// ListSourceFiles walks the directory, returning the files to upload and the paths excluded by
+// the ignore rules. Excluded directories are not walked. Paths are relative to the directory.
+func ListSourceFiles(dir string, ignore *Ignore) ([]SourceFile, []string, error) {
+	files := []SourceFile{}
+	excluded := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if isAlwaysExcluded(rel) || ignore.Match(filepath.ToSlash(rel), info.IsDir()) {
+			excluded = append(excluded, rel)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			files = append(files, SourceFile{Path: rel, Size: info.Size()})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return files, excluded, nil
+}
+
+func isAlwaysExcluded(rel string) bool {
+	for _, path := range alwaysExcluded {
+		if rel == path {
+			return true
+		}
+	}
+	return false
+}
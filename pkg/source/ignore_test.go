@@ -0,0 +1,226 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIgnoreMatch(t *testing.T) {
+	ignore := ParseIgnore(`
+# dependencies
+node_modules
+target/
+/build
+*.log
+!keep.log
+docs/**/*.md
+secrets/[a-c]*.env
+\#notes
+data/[[:digit:]]*.csv
+tmp/[]x]
+keys/[!a-c]*.pem
+[z-a]
+bad/[[:nope:]]
+`)
+	tests := []struct {
+		name     string
+		path     string
+		isDir    bool
+		expected bool
+	}{{
+		name:     "not matched",
+		path:     "main.go",
+		expected: false,
+	}, {
+		name:     "name at root",
+		path:     "node_modules",
+		isDir:    true,
+		expected: true,
+	}, {
+		name:     "name nested",
+		path:     "web/node_modules",
+		isDir:    true,
+		expected: true,
+	}, {
+		name:     "dir only pattern matches dir",
+		path:     "target",
+		isDir:    true,
+		expected: true,
+	}, {
+		name:     "dir only pattern skips file",
+		path:     "target",
+		expected: false,
+	}, {
+		name:     "anchored at root",
+		path:     "build",
+		isDir:    true,
+		expected: true,
+	}, {
+		name:     "anchored not nested",
+		path:     "cmd/build",
+		isDir:    true,
+		expected: false,
+	}, {
+		name:     "wildcard",
+		path:     "logs/debug.log",
+		expected: true,
+	}, {
+		name:     "negated",
+		path:     "logs/keep.log",
+		expected: false,
+	}, {
+		name:     "double star",
+		path:     "docs/a/b/readme.md",
+		expected: true,
+	}, {
+		name:     "double star matches no dir",
+		path:     "docs/readme.md",
+		expected: true,
+	}, {
+		name:     "character class",
+		path:     "secrets/api.env",
+		expected: true,
+	}, {
+		name:     "character class not matched",
+		path:     "secrets/db.env",
+		expected: false,
+	}, {
+		name:     "escaped comment",
+		path:     "#notes",
+		expected: true,
+	}, {
+		name:     "posix class",
+		path:     "data/2022.csv",
+		expected: true,
+	}, {
+		name:     "posix class not matched",
+		path:     "data/latest.csv",
+		expected: false,
+	}, {
+		name:     "leading bracket in class",
+		path:     "tmp/]",
+		expected: true,
+	}, {
+		name:     "class after leading bracket",
+		path:     "tmp/x",
+		expected: true,
+	}, {
+		name:     "negated class",
+		path:     "keys/db.pem",
+		expected: true,
+	}, {
+		name:     "negated class not matched",
+		path:     "keys/api.pem",
+		expected: false,
+	}, {
+		name:     "invalid range is skipped",
+		path:     "z",
+		expected: false,
+	}, {
+		name:     "invalid posix class is skipped",
+		path:     "bad/x",
+		expected: false,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := ignore.Match(test.path, test.isDir); actual != test.expected {
+				t.Errorf("Match(%q) expected %v actual %v", test.path, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestListSourceFiles(t *testing.T) {
+	tests := []struct {
+		name             string
+		files            map[string]string
+		expectedFiles    []SourceFile
+		expectedExcluded []string
+	}{{
+		name: "no ignore file",
+		files: map[string]string{
+			"main.go":       "package main",
+			".git/HEAD":     "ref",
+			".imgpkg/a.yml": "a",
+		},
+		expectedFiles: []SourceFile{
+			{Path: "main.go", Size: 12},
+		},
+		expectedExcluded: []string{".git", ".imgpkg"},
+	}, {
+		name: "tanzuignore",
+		files: map[string]string{
+			IgnoreFileName:            "node_modules/\n*.env\n",
+			GitIgnoreFileName:         "main.go\n",
+			"main.go":                 "package main",
+			".env":                    "TOKEN=x",
+			".git/HEAD":               "ref",
+			"node_modules/a/index.js": "",
+		},
+		expectedFiles: []SourceFile{
+			{Path: GitIgnoreFileName, Size: 8},
+			{Path: IgnoreFileName, Size: 20},
+			{Path: "main.go", Size: 12},
+		},
+		expectedExcluded: []string{".env", ".git", "node_modules"},
+	}, {
+		name: "gitignore fallback",
+		files: map[string]string{
+			GitIgnoreFileName: "target/\n",
+			"pom.xml":         "<project/>",
+			"target/app.jar":  "jar",
+		},
+		expectedFiles: []SourceFile{
+			{Path: GitIgnoreFileName, Size: 8},
+			{Path: "pom.xml", Size: 10},
+		},
+		expectedExcluded: []string{"target"},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range test.files {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ignore, err := LoadIgnore(dir)
+			if err != nil {
+				t.Fatalf("LoadIgnore() errored %v", err)
+			}
+			files, excluded, err := ListSourceFiles(dir, ignore)
+			if err != nil {
+				t.Fatalf("ListSourceFiles() errored %v", err)
+			}
+			if diff := cmp.Diff(test.expectedFiles, files); diff != "" {
+				t.Errorf("ListSourceFiles() files (-expected, +actual): %s", diff)
+			}
+			if diff := cmp.Diff(test.expectedExcluded, excluded); diff != "" {
+				t.Errorf("ListSourceFiles() excluded (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}
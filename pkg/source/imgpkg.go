@@ -19,37 +19,162 @@ package source
 import (
 	"context"
 	"fmt"
-	"path"
+	"io"
 
-	"github.com/cppforlife/go-cli-ui/ui"
 	regname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/match"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
-	"github.com/k14s/imgpkg/pkg/imgpkg/plainimage"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	ctlimg "github.com/k14s/imgpkg/pkg/imgpkg/image"
 	"github.com/k14s/imgpkg/pkg/imgpkg/registry"
 )
 
-func ImgpkgPush(ctx context.Context, dir string, image string) (string, error) {
-	options := RetrieveGgcrRemoteOptions(ctx)
+// ImgpkgPush publishes the contents of the directory as an image, excluding the paths relative
+// to the directory
+func ImgpkgPush(ctx context.Context, dir string, excludedPaths []string, image string) (string, error) {
+	sourceImage, err := NewSourceImage(dir, excludedPaths)
+	if err != nil {
+		return "", err
+	}
+	defer sourceImage.Remove()
 
-	// TODO support more registry options
-	reg, err := registry.NewRegistry(registry.Opts{VerifyCerts: true}, options...)
+	digestedImage, _, err := sourceImage.Push(ctx, image, nil)
+	return digestedImage, err
+}
+
+// SourceImage is the local source packaged as an image, its digest is known before it is
+// published. Timestamps are static and permissions are reduced to the owner bits so that the
+// digest only changes with the contents.
+type SourceImage struct {
+	image *ctlimg.FileImage
+}
+
+// NewSourceImage packages the contents of the directory, excluding the paths relative to the
+// directory. The packaged image must be removed once published.
+func NewSourceImage(dir string, excludedPaths []string) (*SourceImage, error) {
+	image, err := ctlimg.NewTarImage([]string{dir}, excludedPaths, io.Discard).AsFileImage(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SourceImage{image: image}, nil
+}
+
+// Digest of the image, as it would be published
+func (i *SourceImage) Digest() (string, error) {
+	digest, err := i.image.Digest()
+	if err != nil {
+		return "", err
+	}
+	return digest.String(), nil
+}
+
+// Remove the packaged image from the local filesystem
+func (i *SourceImage) Remove() error {
+	return i.image.Remove()
+}
+
+// Push publishes the image, returning the image reference with its tag and digest. The upload
+// progress is reported to onProgress when set, the returned progress is the upload summary.
+func (i *SourceImage) Push(ctx context.Context, image string, onProgress ProgressFunc) (string, Progress, error) {
+	reg, err := newRegistry(ctx)
 	if err != nil {
-		return "", fmt.Errorf("unable to create a registry with provided options: %v", err)
+		return "", Progress{}, err
 	}
 
 	uploadRef, err := regname.NewTag(image, regname.WeakValidation)
+	if err != nil {
+		return "", Progress{}, fmt.Errorf("parsing '%s': %s", image, err)
+	}
+
+	layers, err := i.image.Layers()
+	if err != nil {
+		return "", Progress{}, err
+	}
+	if onProgress == nil {
+		onProgress = func(Progress) {}
+	}
+	tracker, err := newProgressTracker(layers, onProgress)
+	if err != nil {
+		return "", Progress{}, err
+	}
+
+	if err := reg.WriteImage(uploadRef, &progressImage{Image: i.image, tracker: tracker}); err != nil {
+		return "", Progress{}, fmt.Errorf("Writing '%s': %s", uploadRef.Name(), err)
+	}
+
+	digest, err := i.Digest()
+	if err != nil {
+		return "", Progress{}, err
+	}
+	// get an image ref with a tag and digest
+	return fmt.Sprintf("%s@%s", uploadRef.Name(), digest), tracker.summary(), nil
+}
+
+// ociRefNameAnnotation is the OCI annotation naming an image of a layout
+const ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+
+// WriteTarball writes the image to a tarball, tagged with the image it is eventually published to
+func (i *SourceImage) WriteTarball(path string, image string) error {
+	tag, err := regname.NewTag(image, regname.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("parsing '%s': %s", image, err)
+	}
+	if err := tarball.WriteToFile(path, tag, i.image); err != nil {
+		return fmt.Errorf("unable to write tarball %q: %w", path, err)
+	}
+	return nil
+}
+
+// WriteLayout adds the image to an OCI image layout, created when missing. The image is annotated
+// with the image it is eventually published to, replacing the image previously written for it.
+func (i *SourceImage) WriteLayout(dir string, image string) error {
+	tag, err := regname.NewTag(image, regname.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("parsing '%s': %s", image, err)
+	}
+	p, err := layout.FromPath(dir)
+	if err != nil {
+		if p, err = layout.Write(dir, empty.Index); err != nil {
+			return fmt.Errorf("unable to create OCI layout %q: %w", dir, err)
+		}
+	}
+	annotations := map[string]string{ociRefNameAnnotation: tag.Name()}
+	if err := p.ReplaceImage(i.image, match.Annotation(ociRefNameAnnotation, tag.Name()), layout.WithAnnotations(annotations)); err != nil {
+		return fmt.Errorf("unable to write OCI layout %q: %w", dir, err)
+	}
+	return nil
+}
+
+// RemoteDigest looks up the digest of the image tag in the registry
+func RemoteDigest(ctx context.Context, image string) (string, error) {
+	reg, err := newRegistry(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := regname.NewTag(image, regname.WeakValidation)
 	if err != nil {
 		return "", fmt.Errorf("parsing '%s': %s", image, err)
 	}
 
-	digest, err := plainimage.NewContents([]string{dir}, []string{path.Join(dir, ".imgpkg")}).Push(uploadRef, nil, reg, ui.NewNoopUI())
+	digest, err := reg.Digest(ref)
 	if err != nil {
 		return "", err
 	}
+	return digest.String(), nil
+}
 
-	// get an image ref with a tag and digest
-	digestRef, _ := regname.NewDigest(digest, regname.WeakValidation)
-	return fmt.Sprintf("%s@%s", uploadRef.Name(), digestRef.DigestStr()), nil
+func newRegistry(ctx context.Context) (registry.Registry, error) {
+	options := RetrieveGgcrRemoteOptions(ctx)
+
+	// the stashed options, see RegistryOptions, take precedence over the registry defaults
+	reg, err := registry.NewRegistry(registry.Opts{VerifyCerts: true}, options...)
+	if err != nil {
+		return registry.Registry{}, fmt.Errorf("unable to create a registry with provided options: %v", err)
+	}
+	return reg, nil
 }
 
 type ggcrRemoteOptionsStashKey struct{}
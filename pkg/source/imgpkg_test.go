@@ -0,0 +1,168 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+func TestSourceImage(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	image := u.Host + "/hello:source"
+	ctx := StashGgcrRemoteOptions(context.Background(), remote.WithTransport(server.Client().Transport))
+
+	digest := func(dir string, excludedPaths []string) string {
+		sourceImage, err := NewSourceImage(dir, excludedPaths)
+		if err != nil {
+			t.Fatalf("NewSourceImage() errored %v", err)
+		}
+		defer sourceImage.Remove()
+		digest, err := sourceImage.Digest()
+		if err != nil {
+			t.Fatalf("Digest() errored %v", err)
+		}
+		return digest
+	}
+
+	expected := digest("testdata/hello_zip", nil)
+	if actual := digest("testdata/hello_zip", nil); expected != actual {
+		t.Errorf("Digest() expected the same digest for the same contents, %q != %q", expected, actual)
+	}
+	if actual := digest("testdata/hello_jar", nil); expected != actual {
+		t.Errorf("Digest() expected the same digest for the same contents in another dir, %q != %q", expected, actual)
+	}
+	if actual := digest("testdata/hello_zip", []string{"hello.go"}); expected == actual {
+		t.Errorf("Digest() expected a different digest for different contents")
+	}
+
+	if _, err := RemoteDigest(ctx, image); err == nil {
+		t.Errorf("RemoteDigest() expected error for an unpublished image")
+	}
+	sourceImage, err := NewSourceImage("testdata/hello_zip", nil)
+	if err != nil {
+		t.Fatalf("NewSourceImage() errored %v", err)
+	}
+	defer sourceImage.Remove()
+	reported := Progress{}
+	digestedImage, summary, err := sourceImage.Push(ctx, image, func(p Progress) { reported = p })
+	if err != nil {
+		t.Fatalf("Push() errored %v", err)
+	}
+	if expected, actual := image+"@"+expected, digestedImage; expected != actual {
+		t.Errorf("Push() expected %q, actual %q", expected, actual)
+	}
+	if summary.Total == 0 || summary.Complete != summary.Total || summary.Layers != 1 || summary.LayersComplete != 1 {
+		t.Errorf("Push() expected a complete upload summary, actual %+v", summary)
+	}
+	if reported.Complete != summary.Complete || reported.LayersComplete != summary.LayersComplete {
+		t.Errorf("Push() expected the last reported progress %+v to match the summary %+v", reported, summary)
+	}
+	remoteDigest, err := RemoteDigest(ctx, image)
+	if err != nil {
+		t.Fatalf("RemoteDigest() errored %v", err)
+	}
+	if expected != remoteDigest {
+		t.Errorf("RemoteDigest() expected %q, actual %q", expected, remoteDigest)
+	}
+}
+
+func TestSourceImageWrite(t *testing.T) {
+	dir := t.TempDir()
+	image := "registry.example.com/hello:source"
+
+	sourceImage, err := NewSourceImage("testdata/hello_zip", nil)
+	if err != nil {
+		t.Fatalf("NewSourceImage() errored %v", err)
+	}
+	defer sourceImage.Remove()
+	expected, err := sourceImage.Digest()
+	if err != nil {
+		t.Fatalf("Digest() errored %v", err)
+	}
+
+	t.Run("tarball", func(t *testing.T) {
+		path := filepath.Join(dir, "source.tar")
+		if err := sourceImage.WriteTarball(path, image); err != nil {
+			t.Fatalf("WriteTarball() errored %v", err)
+		}
+		tag, _ := name.NewTag(image)
+		img, err := tarball.ImageFromPath(path, &tag)
+		if err != nil {
+			t.Fatalf("unable to read tarball: %v", err)
+		}
+		if actual, _ := img.Digest(); expected != actual.String() {
+			t.Errorf("WriteTarball() expected digest %q, actual %q", expected, actual)
+		}
+	})
+
+	t.Run("oci layout", func(t *testing.T) {
+		path := filepath.Join(dir, "layout")
+		// writing the same reference again replaces the image
+		for i := 0; i < 2; i++ {
+			if err := sourceImage.WriteLayout(path, image); err != nil {
+				t.Fatalf("WriteLayout() errored %v", err)
+			}
+		}
+		otherImage, err := NewSourceImage("testdata/hello_tar", nil)
+		if err != nil {
+			t.Fatalf("NewSourceImage() errored %v", err)
+		}
+		defer otherImage.Remove()
+		if err := otherImage.WriteLayout(path, "registry.example.com/other:source"); err != nil {
+			t.Fatalf("WriteLayout() errored %v", err)
+		}
+
+		p, err := layout.FromPath(path)
+		if err != nil {
+			t.Fatalf("unable to read layout: %v", err)
+		}
+		index, err := p.ImageIndex()
+		if err != nil {
+			t.Fatalf("unable to read layout index: %v", err)
+		}
+		manifest, err := index.IndexManifest()
+		if err != nil {
+			t.Fatalf("unable to read layout index: %v", err)
+		}
+		if expected, actual := 2, len(manifest.Manifests); expected != actual {
+			t.Fatalf("WriteLayout() expected %d images, actual %d", expected, actual)
+		}
+		if expected, actual := "registry.example.com/hello:source", manifest.Manifests[0].Annotations[ociRefNameAnnotation]; expected != actual {
+			t.Errorf("WriteLayout() expected ref name %q, actual %q", expected, actual)
+		}
+		if actual := manifest.Manifests[0].Digest.String(); expected != actual {
+			t.Errorf("WriteLayout() expected digest %q, actual %q", expected, actual)
+		}
+	})
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// Progress of an image upload, in compressed bytes of the image layers
+type Progress struct {
+	Complete       int64
+	Total          int64
+	LayersComplete int
+	Layers         int
+	Elapsed        time.Duration
+}
+
+// Throughput is the average upload rate, in bytes per second
+func (p Progress) Throughput() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Complete) / p.Elapsed.Seconds()
+}
+
+// Remaining is the estimated time to complete the upload at the average rate, zero when unknown
+func (p Progress) Remaining() time.Duration {
+	throughput := p.Throughput()
+	if throughput == 0 || p.Complete >= p.Total {
+		return 0
+	}
+	return time.Duration(float64(p.Total-p.Complete) / throughput * float64(time.Second))
+}
+
+// ProgressFunc is called each time the upload progresses, it must return quickly
+type ProgressFunc func(Progress)
+
+// progressTracker counts the bytes read from the layers of an image while it is uploaded. A layer
+// read again, when the upload is retried, starts over.
+type progressTracker struct {
+	m        sync.Mutex
+	start    time.Time
+	progress Progress
+	read     map[v1.Hash]int64
+	done     map[v1.Hash]bool
+	report   ProgressFunc
+}
+
+func newProgressTracker(layers []v1.Layer, report ProgressFunc) (*progressTracker, error) {
+	t := &progressTracker{
+		start:  time.Now(),
+		read:   map[v1.Hash]int64{},
+		done:   map[v1.Hash]bool{},
+		report: report,
+	}
+	for _, layer := range layers {
+		size, err := layer.Size()
+		if err != nil {
+			return nil, err
+		}
+		t.progress.Total += size
+		t.progress.Layers++
+	}
+	return t, nil
+}
+
+func (t *progressTracker) update(digest v1.Hash, read int64, done bool) {
+	t.m.Lock()
+	t.progress.Complete += read - t.read[digest]
+	t.read[digest] = read
+	if done {
+		t.done[digest] = true
+	}
+	t.progress.LayersComplete = len(t.done)
+	t.progress.Elapsed = time.Since(t.start)
+	progress := t.progress
+	t.m.Unlock()
+
+	t.report(progress)
+}
+
+// summary of the upload once complete
+func (t *progressTracker) summary() Progress {
+	t.m.Lock()
+	defer t.m.Unlock()
+	progress := t.progress
+	progress.Elapsed = time.Since(t.start)
+	return progress
+}
+
+// progressImage reports the bytes read from its layers to the tracker
+type progressImage struct {
+	v1.Image
+	tracker *progressTracker
+}
+
+func (i *progressImage) Layers() ([]v1.Layer, error) {
+	layers, err := i.Image.Layers()
+	if err != nil {
+		return nil, err
+	}
+	wrapped := make([]v1.Layer, len(layers))
+	for n, layer := range layers {
+		wrapped[n] = &progressLayer{Layer: layer, tracker: i.tracker}
+	}
+	return wrapped, nil
+}
+
+func (i *progressImage) LayerByDigest(digest v1.Hash) (v1.Layer, error) {
+	layer, err := i.Image.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	return &progressLayer{Layer: layer, tracker: i.tracker}, nil
+}
+
+type progressLayer struct {
+	v1.Layer
+	tracker *progressTracker
+}
+
+func (l *progressLayer) Compressed() (io.ReadCloser, error) {
+	rc, err := l.Layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	digest, err := l.Layer.Digest()
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	l.tracker.update(digest, 0, false)
+	return &progressReader{ReadCloser: rc, digest: digest, tracker: l.tracker}, nil
+}
+
+type progressReader struct {
+	io.ReadCloser
+	digest  v1.Hash
+	tracker *progressTracker
+	read    int64
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.read += int64(n)
+	r.tracker.update(r.digest, r.read, err == io.EOF)
+	return n, err
+}
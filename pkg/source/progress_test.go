@@ -0,0 +1,53 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgress(t *testing.T) {
+	tests := []struct {
+		name               string
+		progress           Progress
+		expectedThroughput float64
+		expectedRemaining  time.Duration
+	}{{
+		name:     "not started",
+		progress: Progress{Total: 100},
+	}, {
+		name:               "in progress",
+		progress:           Progress{Complete: 20, Total: 100, Elapsed: 2 * time.Second},
+		expectedThroughput: 10,
+		expectedRemaining:  8 * time.Second,
+	}, {
+		name:               "complete",
+		progress:           Progress{Complete: 100, Total: 100, Elapsed: 4 * time.Second},
+		expectedThroughput: 25,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if expected, actual := test.expectedThroughput, test.progress.Throughput(); expected != actual {
+				t.Errorf("Throughput() expected %v, actual %v", expected, actual)
+			}
+			if expected, actual := test.expectedRemaining, test.progress.Remaining(); expected != actual {
+				t.Errorf("Remaining() expected %v, actual %v", expected, actual)
+			}
+		})
+	}
+}
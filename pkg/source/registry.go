@@ -0,0 +1,134 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	"github.com/google/go-containerregistry/pkg/authn"
+	regname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// RegistryOptions configures the access to the registry the local source is published to
+type RegistryOptions struct {
+	// CACertPaths are PEM files of certificate authorities trusted along with the system ones
+	CACertPaths []string
+	// VerifyCerts checks the certificate of the registry, it is skipped when false
+	VerifyCerts bool
+	Username    string
+	Password    string
+	// ConfigFile is a docker config file with the registry credentials
+	ConfigFile string
+}
+
+// baseTransport is the transport customized with the TLS options
+var baseTransport = func() *http.Transport {
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+// RemoteOptions are the options to stash with StashGgcrRemoteOptions. The transport and the
+// credentials are only overridden when set, leaving the defaults in place otherwise.
+func (o RegistryOptions) RemoteOptions() ([]remote.Option, error) {
+	options := []remote.Option{}
+
+	if len(o.CACertPaths) != 0 || !o.VerifyCerts {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, path := range o.CACertPaths {
+			certs, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read CA certificates from %q: %w", path, err)
+			}
+			if ok := pool.AppendCertsFromPEM(certs); !ok {
+				return nil, fmt.Errorf("no CA certificates found in %q", path)
+			}
+		}
+		transport := baseTransport()
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:            pool,
+			InsecureSkipVerify: !o.VerifyCerts,
+		}
+		options = append(options, remote.WithTransport(transport))
+	}
+
+	switch {
+	case o.Username != "":
+		options = append(options, remote.WithAuthFromKeychain(basicKeychain{
+			username: o.Username,
+			password: o.Password,
+		}))
+	case o.ConfigFile != "":
+		file, err := os.Open(o.ConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read registry config %q: %w", o.ConfigFile, err)
+		}
+		defer file.Close()
+		config, err := dockerconfig.LoadFromReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse registry config %q: %w", o.ConfigFile, err)
+		}
+		options = append(options, remote.WithAuthFromKeychain(dockerConfigKeychain{config: config}))
+	}
+
+	return options, nil
+}
+
+type basicKeychain struct {
+	username string
+	password string
+}
+
+func (k basicKeychain) Resolve(authn.Resource) (authn.Authenticator, error) {
+	return &authn.Basic{Username: k.username, Password: k.password}, nil
+}
+
+// dockerConfigKeychain resolves credentials from a docker config file, like authn.DefaultKeychain
+// does for the config file of the current user
+type dockerConfigKeychain struct {
+	config *configfile.ConfigFile
+}
+
+func (k dockerConfigKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	key := target.RegistryStr()
+	if key == regname.DefaultRegistry {
+		key = authn.DefaultAuthKey
+	}
+	cfg, err := k.config.GetAuthConfig(key)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == (types.AuthConfig{}) {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(authn.AuthConfig{
+		Username:      cfg.Username,
+		Password:      cfg.Password,
+		Auth:          cfg.Auth,
+		IdentityToken: cfg.IdentityToken,
+		RegistryToken: cfg.RegistryToken,
+	}), nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+)
+
+func TestImgpkgPushWithRegistryOptions(t *testing.T) {
+	// the httptest certificate is valid for example.com, requests to it are sent to the server
+	reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "robot" || password != "s3cret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	restoreTransport := baseTransport
+	defer func() { baseTransport = restoreTransport }()
+	baseTransport = func() *http.Transport {
+		transport := restoreTransport()
+		transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+		}
+		return transport
+	}
+
+	dir := t.TempDir()
+	caCert := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	invalidCACert := filepath.Join(dir, "invalid.crt")
+	if err := os.WriteFile(invalidCACert, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	dockerConfig := filepath.Join(dir, "config.json")
+	auth := base64.StdEncoding.EncodeToString([]byte("robot:s3cret"))
+	if err := os.WriteFile(dockerConfig, []byte(fmt.Sprintf(`{"auths":{"example.com":{"auth":%q}}}`, auth)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		options     RegistryOptions
+		expectedErr string
+	}{{
+		name: "ca cert and credentials",
+		options: RegistryOptions{
+			CACertPaths: []string{caCert},
+			VerifyCerts: true,
+			Username:    "robot",
+			Password:    "s3cret",
+		},
+	}, {
+		name: "skip cert verification",
+		options: RegistryOptions{
+			Username: "robot",
+			Password: "s3cret",
+		},
+	}, {
+		name: "docker config",
+		options: RegistryOptions{
+			CACertPaths: []string{caCert},
+			VerifyCerts: true,
+			ConfigFile:  dockerConfig,
+		},
+	}, {
+		name: "invalid credentials",
+		options: RegistryOptions{
+			CACertPaths: []string{caCert},
+			VerifyCerts: true,
+			Username:    "robot",
+			Password:    "guess",
+		},
+		expectedErr: "401 Unauthorized",
+	}, {
+		name: "missing ca cert",
+		options: RegistryOptions{
+			CACertPaths: []string{filepath.Join(dir, "missing.crt")},
+			VerifyCerts: true,
+		},
+		expectedErr: "unable to read CA certificates from",
+	}, {
+		name: "invalid ca cert",
+		options: RegistryOptions{
+			CACertPaths: []string{invalidCACert},
+			VerifyCerts: true,
+		},
+		expectedErr: "no CA certificates found in",
+	}, {
+		name: "missing docker config",
+		options: RegistryOptions{
+			ConfigFile: filepath.Join(dir, "missing.json"),
+		},
+		expectedErr: "unable to read registry config",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			options, err := test.options.RemoteOptions()
+			if err == nil {
+				ctx = StashGgcrRemoteOptions(ctx, options...)
+				_, err = ImgpkgPush(ctx, "testdata/hello_zip", nil, "example.com/hello:source")
+			}
+			if test.expectedErr == "" && err != nil {
+				t.Fatalf("ImgpkgPush() errored %v", err)
+			}
+			if test.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), test.expectedErr)) {
+				t.Fatalf("ImgpkgPush() expected error containing %q, actual %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRegistryOptionsDefaults(t *testing.T) {
+	options, err := RegistryOptions{VerifyCerts: true}.RemoteOptions()
+	if err != nil {
+		t.Fatalf("RemoteOptions() errored %v", err)
+	}
+	if len(options) != 0 {
+		t.Errorf("RemoteOptions() expected no options to override the defaults, actual %d", len(options))
+	}
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	regname "github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/k14s/imgpkg/pkg/imgpkg/registry"
+)
+
+// media types and annotations of the cosign signature and attestation layout
+const (
+	cosignSignatureMediaType      types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignAttestationMediaType    types.MediaType = "application/vnd.dsse.envelope.v1+json"
+	cosignSignatureAnnotation                     = "dev.cosignproject.cosign/signature"
+	cosignPredicateTypeAnnotation                 = "predicateType"
+
+	inTotoPayloadType   = "application/vnd.in-toto+json"
+	inTotoStatementType = "https://in-toto.io/Statement/v0.1"
+)
+
+// ProvenancePredicateType is the type of the attestation attached to signed source images
+const ProvenancePredicateType = "https://github.com/vmware-tanzu/apps-cli-plugin/source-provenance/v0.1"
+
+// Provenance of the published source, attested when the source image is signed
+type Provenance struct {
+	// GitCommit is the commit checked out in the local source, when it is a git repository
+	GitCommit string `json:"gitCommit,omitempty"`
+	// GitDirty is set when the local source has changes that are not committed
+	GitDirty bool `json:"gitDirty"`
+	// Uploader is who published the source
+	Uploader string `json:"uploader,omitempty"`
+	// LocalPath is the path of the published source
+	LocalPath string `json:"localPath,omitempty"`
+}
+
+// Signer signs published source images with a local key. The signature and the attestation are
+// pushed next to the image, in the cosign layout, so they can be verified with the public key.
+type Signer struct {
+	key crypto.Signer
+}
+
+// LoadSigner reads an unencrypted PEM private key, ECDSA, RSA or Ed25519. The encrypted keys of
+// cosign are not supported.
+func LoadSigner(path string) (*Signer, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read signing key %q: %w", path, err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM private key found in %q", path)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "ENCRYPTED COSIGN PRIVATE KEY", "ENCRYPTED SIGSTORE PRIVATE KEY":
+		return nil, fmt.Errorf("unsupported signing key %q, encrypted cosign keys are not supported, use an unencrypted PKCS#8 or EC private key", path)
+	default:
+		return nil, fmt.Errorf("unsupported signing key %q of type %q, only unencrypted private keys are supported", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signing key %q: %w", path, err)
+	}
+
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		return &Signer{key: key}, nil
+	case *rsa.PrivateKey:
+		return &Signer{key: key}, nil
+	case ed25519.PrivateKey:
+		return &Signer{key: key}, nil
+	}
+	return nil, fmt.Errorf("unsupported signing key %q of type %T", path, key)
+}
+
+// Sign pushes a signature of the digested image, and an attestation of its provenance. Like cosign,
+// the signature and the attestation are appended to the ones already pushed for the image, unless
+// the same payload is already signed with the key.
+func (s *Signer) Sign(ctx context.Context, digestedImage string, provenance Provenance) error {
+	ref, err := regname.NewDigest(digestedImage, regname.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("parsing '%s': %s", digestedImage, err)
+	}
+	reg, err := newRegistry(ctx)
+	if err != nil {
+		return err
+	}
+
+	signature, err := s.signatureLayer(ref)
+	if err != nil {
+		return err
+	}
+	if err := appendSignedLayer(reg, cosignTag(ref, "sig"), signature); err != nil {
+		return fmt.Errorf("unable to push signature: %s", err)
+	}
+
+	attestation, err := s.attestationLayer(ref, provenance)
+	if err != nil {
+		return err
+	}
+	if err := appendSignedLayer(reg, cosignTag(ref, "att"), attestation); err != nil {
+		return fmt.Errorf("unable to push attestation: %s", err)
+	}
+	return nil
+}
+
+// signedLayer is a layer of a cosign image, signedBy checks if the content and annotations of an
+// existing layer sign the same payload with the key
+type signedLayer struct {
+	mutate.Addendum
+	signedBy func(content []byte, annotations map[string]string) bool
+}
+
+// appendSignedLayer adds the layer to the image of the tag, keeping the layers of other signers. The
+// image is created when the tag is not found, and left as is when it already has the layer.
+func appendSignedLayer(reg registry.Registry, tag regname.Tag, layer signedLayer) error {
+	base, err := reg.Image(tag)
+	if err != nil {
+		var terr *transport.Error
+		if !errors.As(err, &terr) || terr.StatusCode != http.StatusNotFound {
+			return err
+		}
+		base = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	} else if signed, err := hasSignedLayer(base, layer); err != nil || signed {
+		return err
+	}
+	image, err := mutate.Append(base, layer.Addendum)
+	if err != nil {
+		return err
+	}
+	return reg.WriteImage(tag, image)
+}
+
+func hasSignedLayer(image v1.Image, layer signedLayer) (bool, error) {
+	manifest, err := image.Manifest()
+	if err != nil {
+		return false, err
+	}
+	mediaType, err := layer.Layer.MediaType()
+	if err != nil {
+		return false, err
+	}
+	for _, descriptor := range manifest.Layers {
+		if descriptor.MediaType != mediaType {
+			continue
+		}
+		l, err := image.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return false, err
+		}
+		rc, err := l.Compressed()
+		if err != nil {
+			return false, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return false, err
+		}
+		if layer.signedBy(content, descriptor.Annotations) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cosignTag is the tag of the signature or attestation of a digest, in the repository of the image
+func cosignTag(ref regname.Digest, suffix string) regname.Tag {
+	return ref.Context().Tag(fmt.Sprintf("%s.%s", strings.Replace(ref.DigestStr(), ":", "-", 1), suffix))
+}
+
+func (s *Signer) signatureLayer(ref regname.Digest) (signedLayer, error) {
+	// the simple signing payload, as signed by cosign
+	payload, err := json.Marshal(map[string]interface{}{
+		"critical": map[string]interface{}{
+			"identity": map[string]string{"docker-reference": ref.Context().Name()},
+			"image":    map[string]string{"docker-manifest-digest": ref.DigestStr()},
+			"type":     "cosign container image signature",
+		},
+		"optional": nil,
+	})
+	if err != nil {
+		return signedLayer{}, err
+	}
+	signature, err := s.sign(payload)
+	if err != nil {
+		return signedLayer{}, err
+	}
+	return signedLayer{
+		Addendum: mutate.Addendum{
+			Layer: static.NewLayer(payload, cosignSignatureMediaType),
+			Annotations: map[string]string{
+				cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+			},
+		},
+		signedBy: func(content []byte, annotations map[string]string) bool {
+			signature, err := base64.StdEncoding.DecodeString(annotations[cosignSignatureAnnotation])
+			return err == nil && bytes.Equal(content, payload) && s.verify(payload, signature)
+		},
+	}, nil
+}
+
+// dsseEnvelope is the signed envelope of an attestation
+type dsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+type dsseSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+func (s *Signer) attestationLayer(ref regname.Digest, provenance Provenance) (signedLayer, error) {
+	hash, err := v1.NewHash(ref.DigestStr())
+	if err != nil {
+		return signedLayer{}, err
+	}
+	statement, err := json.Marshal(map[string]interface{}{
+		"_type":         inTotoStatementType,
+		"predicateType": ProvenancePredicateType,
+		"subject": []map[string]interface{}{{
+			"name":   ref.Context().Name(),
+			"digest": map[string]string{hash.Algorithm: hash.Hex},
+		}},
+		"predicate": provenance,
+	})
+	if err != nil {
+		return signedLayer{}, err
+	}
+	signature, err := s.sign(dssePAE(inTotoPayloadType, statement))
+	if err != nil {
+		return signedLayer{}, err
+	}
+	envelope, err := json.Marshal(dsseEnvelope{
+		PayloadType: inTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(statement),
+		Signatures: []dsseSignature{{
+			Sig: base64.StdEncoding.EncodeToString(signature),
+		}},
+	})
+	if err != nil {
+		return signedLayer{}, err
+	}
+	return signedLayer{
+		Addendum: mutate.Addendum{
+			Layer: static.NewLayer(envelope, cosignAttestationMediaType),
+			Annotations: map[string]string{
+				cosignPredicateTypeAnnotation: ProvenancePredicateType,
+			},
+		},
+		signedBy: func(content []byte, annotations map[string]string) bool {
+			existing := dsseEnvelope{}
+			if err := json.Unmarshal(content, &existing); err != nil {
+				return false
+			}
+			if payload, err := base64.StdEncoding.DecodeString(existing.Payload); err != nil || !bytes.Equal(payload, statement) {
+				return false
+			}
+			for _, sig := range existing.Signatures {
+				signature, err := base64.StdEncoding.DecodeString(sig.Sig)
+				if err == nil && s.verify(dssePAE(existing.PayloadType, statement), signature) {
+					return true
+				}
+			}
+			return false
+		},
+	}, nil
+}
+
+// dssePAE is the pre-authentication encoding signed for DSSE envelopes
+func dssePAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func (s *Signer) sign(payload []byte) ([]byte, error) {
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		return s.key.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(payload)
+	return s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// verify checks the signature of the payload with the public key of the signer
+func (s *Signer) verify(payload, signature []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch key := s.key.Public().(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	gotemplate "text/template"
+	"text/template/parse"
+)
+
+// variablePattern matches ${NAME} placeholders, a placeholder prefixed with an extra $ is escaped
+var variablePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// UnresolvedVariablesError lists the variables referenced by a template that have no value
+type UnresolvedVariablesError struct {
+	Names []string
+}
+
+func (e *UnresolvedVariablesError) Error() string {
+	return fmt.Sprintf("unresolved variables: %s", strings.Join(e.Names, ", "))
+}
+
+// Render resolves the placeholders of a template with the variables. A variable is referenced
+// either as a Go template field, {{ .NAME }}, or as ${NAME}. Use $${NAME} for a literal ${NAME}.
+// Every variable referenced must have a value, otherwise an UnresolvedVariablesError is returned.
+func Render(name string, content []byte, vars map[string]string) ([]byte, error) {
+	// ${NAME} is rewritten as a Go template action, so both styles are resolved in one pass
+	text := variablePattern.ReplaceAllStringFunc(string(content), func(placeholder string) string {
+		if strings.HasPrefix(placeholder, "$$") {
+			return fmt.Sprintf("{{%q}}", placeholder[1:])
+		}
+		return fmt.Sprintf("{{.%s}}", placeholder[2:len(placeholder)-1])
+	})
+
+	t, err := gotemplate.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := map[string]bool{}
+	if t.Tree != nil {
+		collectVariables(t.Tree.Root, referenced)
+	}
+	unresolved := []string{}
+	for v := range referenced {
+		if _, ok := vars[v]; !ok {
+			unresolved = append(unresolved, v)
+		}
+	}
+	if len(unresolved) != 0 {
+		sort.Strings(unresolved)
+		return nil, &UnresolvedVariablesError{Names: unresolved}
+	}
+
+	out := &bytes.Buffer{}
+	if err := t.Execute(out, vars); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// collectVariables records the top level fields referenced by the template
+func collectVariables(node parse.Node, names map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectVariables(child, names)
+		}
+	case *parse.ActionNode:
+		collectVariables(n.Pipe, names)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectVariables(cmd, names)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectVariables(arg, names)
+		}
+	case *parse.ChainNode:
+		collectVariables(n.Node, names)
+	case *parse.FieldNode:
+		names[n.Ident[0]] = true
+	case *parse.IfNode:
+		collectVariables(n.Pipe, names)
+		collectVariables(n.List, names)
+		collectVariables(n.ElseList, names)
+	case *parse.RangeNode:
+		collectVariables(n.Pipe, names)
+		collectVariables(n.List, names)
+		collectVariables(n.ElseList, names)
+	case *parse.WithNode:
+		collectVariables(n.Pipe, names)
+		collectVariables(n.List, names)
+		collectVariables(n.ElseList, names)
+	case *parse.TemplateNode:
+		collectVariables(n.Pipe, names)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 VMware, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/vmware-tanzu/apps-cli-plugin/pkg/template"
+)
+
+func TestRender(t *testing.T) {
+	vars := map[string]string{
+		"NAME":   "my-workload",
+		"BRANCH": "feature",
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		expected    string
+		expectedErr error
+	}{{
+		name:     "no placeholders",
+		content:  "name: my-workload\n",
+		expected: "name: my-workload\n",
+	}, {
+		name:     "go template",
+		content:  "name: {{ .NAME }}\nbranch: {{.BRANCH}}\n",
+		expected: "name: my-workload\nbranch: feature\n",
+	}, {
+		name:     "variable",
+		content:  "name: ${NAME}\nbranch: ${BRANCH}\n",
+		expected: "name: my-workload\nbranch: feature\n",
+	}, {
+		name:     "mixed",
+		content:  "name: ${NAME}-{{ .BRANCH }}\n",
+		expected: "name: my-workload-feature\n",
+	}, {
+		name:     "escaped variable",
+		content:  "script: echo $${HOME} $HOME\n",
+		expected: "script: echo ${HOME} $HOME\n",
+	}, {
+		name:     "go template functions",
+		content:  "{{ if eq .BRANCH \"main\" }}env: prod{{ else }}env: {{ .BRANCH | printf \"%q\" }}{{ end }}\n",
+		expected: "env: \"feature\"\n",
+	}, {
+		name:        "unresolved variables",
+		content:     "name: ${NAME}\nrepo: ${REPO}\n{{ if .DEBUG }}debug: true{{ end }}\nimage: {{ .REPO }}\n",
+		expectedErr: fmt.Errorf("unresolved variables: DEBUG, REPO"),
+	}, {
+		name:        "invalid template",
+		content:     "name: {{ .NAME }\n",
+		expectedErr: fmt.Errorf("template: workload.yaml:1: unexpected \"}\" in operand"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := template.Render("workload.yaml", []byte(test.content), vars)
+			if fmt.Sprint(test.expectedErr) != fmt.Sprint(err) {
+				t.Errorf("Render() error = %v, expected %v", err, test.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.expected, string(actual)); diff != "" {
+				t.Errorf("Render() = (-expected, +actual): %s", diff)
+			}
+		})
+	}
+}